@@ -0,0 +1,621 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/redhat-cop/group-sync-operator/api/v1beta1"
+)
+
+// HubSpecAnnotation preserves the fields of a v1beta1 GroupSync that cannot be represented in v1alpha1,
+// including the fields of the status
+const HubSpecAnnotation = "group-sync-operator.redhat-cop.io/v1beta1-spec"
+
+// hubOnlySpecFields are the fields of the v1beta1 spec that have no v1alpha1 equivalent
+var hubOnlySpecFields = []string{"composites"}
+
+// hubOnlyStatusFields are the fields of the v1beta1 status that have no v1alpha1 equivalent
+var hubOnlyStatusFields = []string{"providers", "limitViolations"}
+
+// hubOnlyHTTPClientFields are the fields of the v1beta1 HTTP client options shared by providers
+var hubOnlyHTTPClientFields = []string{"clientCertificate", "minTLSVersion", "proxy", "timeout"}
+
+// hubOnlyProviderCommonFields are the fields shared by all v1beta1 provider types that have no v1alpha1 equivalent
+var hubOnlyProviderCommonFields = []string{"limits"}
+
+// hubOnlyProviderFields are the fields of each v1beta1 provider that have no v1alpha1 equivalent
+var hubOnlyProviderFields = map[string][]string{
+	"azure":             append([]string{"groupPatterns", "privilegedAccessExpiry"}, hubOnlyHTTPClientFields...),
+	"github":            append([]string{"hierarchy", "organizations", "allOrganizations", "scope", "teamMemberRole", "maintainerGroups", "ownersGroup", "api", "userMapping", "verifiedEmailDomain", "emuShortcode", "unmappedUserPolicy", "enterprise", "teamPatterns", "teamPrivacy", "repositories"}, hubOnlyHTTPClientFields...),
+	"gitlab":            append([]string{"hierarchy", "groupPatterns", "minAccessLevel", "accessLevelGroups", "memberStates", "excludeBots", "userMapping", "identityProvider", "unmappedUserPolicy"}, hubOnlyHTTPClientFields...),
+	"keycloak":          append([]string{"membershipExpiryAttribute", "groupPatterns"}, hubOnlyHTTPClientFields...),
+	"okta":              append([]string{"ca", "insecure", "groupPatterns"}, hubOnlyHTTPClientFields...),
+	"ibmsecurityverify": append([]string{"ca", "insecure", "prune"}, hubOnlyHTTPClientFields...),
+}
+
+// hubOnlyProviderTypes are the v1beta1 provider types that have no v1alpha1 equivalent
+var hubOnlyProviderTypes = []string{"external", "http", "static", "file"}
+
+// ConvertTo converts this GroupSync to the hub version
+func (src *GroupSync) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.GroupSync)
+
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	hubSpec, hasHubSpec := dst.Annotations[HubSpecAnnotation]
+	delete(dst.Annotations, HubSpecAnnotation)
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+
+	dst.Spec = v1beta1.GroupSyncSpec{
+		Schedule:                 src.Spec.Schedule,
+		ExcludeInvalidGroupNames: src.Spec.ExcludeInvalidGroupNames,
+	}
+
+	for _, provider := range src.Spec.Providers {
+		dst.Spec.Providers = append(dst.Spec.Providers, convertProviderToHub(provider))
+	}
+
+	for _, target := range src.Spec.Targets {
+		dst.Spec.Targets = append(dst.Spec.Targets, v1beta1.Target{
+			Name:             target.Name,
+			KubeconfigSecret: convertObjectRefToHub(target.KubeconfigSecret),
+		})
+	}
+
+	dst.Status = v1beta1.GroupSyncStatus{
+		Conditions:          src.Status.Conditions,
+		LastSyncSuccessTime: src.Status.LastSyncSuccessTime,
+	}
+
+	for _, targetStatus := range src.Status.Targets {
+		dst.Status.Targets = append(dst.Status.Targets, v1beta1.TargetStatus{
+			Name:                targetStatus.Name,
+			Conditions:          targetStatus.Conditions,
+			LastSyncSuccessTime: targetStatus.LastSyncSuccessTime,
+			GroupsSynchronized:  targetStatus.GroupsSynchronized,
+			GroupsPruned:        targetStatus.GroupsPruned,
+		})
+	}
+
+	if hasHubSpec {
+		return restoreHubOnlyFields(&dst.Spec, &dst.Status, hubSpec)
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the hub version to this GroupSync
+func (dst *GroupSync) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.GroupSync)
+
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+
+	dst.Spec = GroupSyncSpec{
+		Schedule:                 src.Spec.Schedule,
+		ExcludeInvalidGroupNames: src.Spec.ExcludeInvalidGroupNames,
+	}
+
+	for _, provider := range src.Spec.Providers {
+		dst.Spec.Providers = append(dst.Spec.Providers, convertProviderFromHub(provider))
+	}
+
+	for _, target := range src.Spec.Targets {
+		dst.Spec.Targets = append(dst.Spec.Targets, Target{
+			Name:             target.Name,
+			KubeconfigSecret: convertObjectRefFromHub(target.KubeconfigSecret),
+		})
+	}
+
+	dst.Status = GroupSyncStatus{
+		Conditions:          src.Status.Conditions,
+		LastSyncSuccessTime: src.Status.LastSyncSuccessTime,
+	}
+
+	for _, targetStatus := range src.Status.Targets {
+		dst.Status.Targets = append(dst.Status.Targets, TargetStatus{
+			Name:                targetStatus.Name,
+			Conditions:          targetStatus.Conditions,
+			LastSyncSuccessTime: targetStatus.LastSyncSuccessTime,
+			GroupsSynchronized:  targetStatus.GroupsSynchronized,
+			GroupsPruned:        targetStatus.GroupsPruned,
+		})
+	}
+
+	hubSpec, err := extractHubOnlyFields(&src.Spec, &src.Status)
+	if err != nil {
+		return err
+	}
+
+	if hubSpec != "" {
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[HubSpecAnnotation] = hubSpec
+	} else {
+		delete(dst.Annotations, HubSpecAnnotation)
+	}
+
+	return nil
+}
+
+func convertProviderToHub(src Provider) v1beta1.Provider {
+	dst := v1beta1.Provider{Name: src.Name}
+
+	if src.ProviderType == nil {
+		return dst
+	}
+
+	dst.ProviderType = &v1beta1.ProviderType{}
+
+	if azure := src.Azure; azure != nil {
+		dst.Azure = &v1beta1.AzureProvider{
+			ProviderBase:       convertProviderBaseToHub(azure.Ca, azure.CaSecret, azure.CredentialsSecret, azure.Insecure, azure.Prune),
+			GroupFilter:        v1beta1.GroupFilter{Groups: azure.Groups},
+			BaseGroups:         azure.BaseGroups,
+			Filter:             azure.Filter,
+			ClientFilter:       azure.ClientFilter,
+			AuthorityHost:      ptr.Deref(azure.AuthorityHost, ""),
+			UserNameAttributes: ptr.Deref(azure.UserNameAttributes, nil),
+		}
+	}
+
+	if github := src.GitHub; github != nil {
+		dst.GitHub = &v1beta1.GitHubProvider{
+			ProviderBase: convertProviderBaseToHub(github.Ca, github.CaSecret, github.CredentialsSecret, github.Insecure, github.Prune),
+			Organization: github.Organization,
+			Teams:        github.Teams,
+			MapByScimId:  github.MapByScimId,
+			URL:          ptr.Deref(github.URL, ""),
+			V4URL:        ptr.Deref(github.V4URL, ""),
+		}
+	}
+
+	if gitlab := src.GitLab; gitlab != nil {
+		dst.GitLab = &v1beta1.GitLabProvider{
+			ProviderBase: convertProviderBaseToHub(gitlab.Ca, gitlab.CaSecret, gitlab.CredentialsSecret, gitlab.Insecure, gitlab.Prune),
+			GroupFilter:  v1beta1.GroupFilter{Groups: gitlab.Groups},
+			Scope:        v1beta1.SyncScope(gitlab.Scope),
+			URL:          ptr.Deref(gitlab.URL, ""),
+		}
+	}
+
+	if ldap := src.Ldap; ldap != nil {
+		dst.Ldap = &v1beta1.LdapProvider{
+			ProviderBase:                            convertProviderBaseToHub(ldap.Ca, ldap.CaSecret, ldap.CredentialsSecret, ldap.Insecure, ldap.Prune),
+			LDAPGroupUIDToOpenShiftGroupNameMapping: ldap.LDAPGroupUIDToOpenShiftGroupNameMapping,
+			RFC2307Config:                           ldap.RFC2307Config,
+			ActiveDirectoryConfig:                   ldap.ActiveDirectoryConfig,
+			AugmentedActiveDirectoryConfig:          ldap.AugmentedActiveDirectoryConfig,
+			URL:                                     ptr.Deref(ldap.URL, ""),
+			Whitelist:                               ptr.Deref(ldap.Whitelist, nil),
+			Blacklist:                               ptr.Deref(ldap.Blacklist, nil),
+		}
+	}
+
+	if keycloak := src.Keycloak; keycloak != nil {
+		dst.Keycloak = &v1beta1.KeycloakProvider{
+			ProviderBase: convertProviderBaseToHub(keycloak.Ca, keycloak.CaSecret, keycloak.CredentialsSecret, keycloak.Insecure, keycloak.Prune),
+			GroupFilter:  v1beta1.GroupFilter{Groups: keycloak.Groups},
+			LoginRealm:   keycloak.LoginRealm,
+			Realm:        keycloak.Realm,
+			Scope:        v1beta1.SyncScope(keycloak.Scope),
+			URL:          keycloak.URL,
+		}
+	}
+
+	if okta := src.Okta; okta != nil {
+		dst.Okta = &v1beta1.OktaProvider{
+			ProviderBase:         convertProviderBaseToHub(nil, nil, okta.CredentialsSecret, false, okta.Prune),
+			GroupFilter:          v1beta1.GroupFilter{Groups: okta.Groups},
+			URL:                  okta.URL,
+			AppId:                okta.AppId,
+			ExtractLoginUsername: okta.ExtractLoginUsername,
+			ProfileKey:           okta.ProfileKey,
+			GroupLimit:           okta.GroupLimit,
+		}
+	}
+
+	if isv := src.IbmSecurityVerify; isv != nil {
+		dst.IbmSecurityVerify = &v1beta1.IbmSecurityVerifyProvider{
+			ProviderBase: convertProviderBaseToHub(nil, nil, isv.CredentialsSecret, false, false),
+			TenantURL:    isv.TenantURL,
+		}
+		for _, group := range isv.Groups {
+			dst.IbmSecurityVerify.Groups = append(dst.IbmSecurityVerify.Groups, v1beta1.IsvGroupSpec{Name: group.Name, Id: group.Id})
+		}
+	}
+
+	return dst
+}
+
+func convertProviderFromHub(src v1beta1.Provider) Provider {
+	dst := Provider{Name: src.Name}
+
+	if src.ProviderType == nil {
+		return dst
+	}
+
+	dst.ProviderType = &ProviderType{}
+
+	if azure := src.Azure; azure != nil {
+		dst.Azure = &AzureProvider{
+			Ca:                 convertObjectRefFromHub(azure.Ca),
+			CredentialsSecret:  convertObjectRefFromHub(azure.CredentialsSecret),
+			Insecure:           azure.Insecure,
+			Prune:              azure.Prune,
+			Groups:             azure.Groups,
+			BaseGroups:         azure.BaseGroups,
+			Filter:             azure.Filter,
+			ClientFilter:       azure.ClientFilter,
+			AuthorityHost:      stringPtrOrNil(azure.AuthorityHost),
+			UserNameAttributes: stringSlicePtrOrNil(azure.UserNameAttributes),
+		}
+	}
+
+	if github := src.GitHub; github != nil {
+		dst.GitHub = &GitHubProvider{
+			Ca:                convertObjectRefFromHub(github.Ca),
+			CredentialsSecret: convertObjectRefFromHub(github.CredentialsSecret),
+			Insecure:          github.Insecure,
+			Prune:             github.Prune,
+			Organization:      github.Organization,
+			Teams:             github.Teams,
+			MapByScimId:       github.MapByScimId,
+			URL:               stringPtrOrNil(github.URL),
+			V4URL:             stringPtrOrNil(github.V4URL),
+		}
+	}
+
+	if gitlab := src.GitLab; gitlab != nil {
+		dst.GitLab = &GitLabProvider{
+			Ca:                convertObjectRefFromHub(gitlab.Ca),
+			CredentialsSecret: convertObjectRefFromHub(gitlab.CredentialsSecret),
+			Insecure:          gitlab.Insecure,
+			Prune:             gitlab.Prune,
+			Groups:            gitlab.Groups,
+			Scope:             SyncScope(gitlab.Scope),
+			URL:               stringPtrOrNil(gitlab.URL),
+		}
+	}
+
+	if ldap := src.Ldap; ldap != nil {
+		dst.Ldap = &LdapProvider{
+			Ca:                                      convertObjectRefFromHub(ldap.Ca),
+			CredentialsSecret:                       convertObjectRefFromHub(ldap.CredentialsSecret),
+			Insecure:                                ldap.Insecure,
+			Prune:                                   ldap.Prune,
+			LDAPGroupUIDToOpenShiftGroupNameMapping: ldap.LDAPGroupUIDToOpenShiftGroupNameMapping,
+			RFC2307Config:                           ldap.RFC2307Config,
+			ActiveDirectoryConfig:                   ldap.ActiveDirectoryConfig,
+			AugmentedActiveDirectoryConfig:          ldap.AugmentedActiveDirectoryConfig,
+			URL:                                     ptr.To(ldap.URL),
+			Whitelist:                               stringSlicePtrOrNil(ldap.Whitelist),
+			Blacklist:                               stringSlicePtrOrNil(ldap.Blacklist),
+		}
+	}
+
+	if keycloak := src.Keycloak; keycloak != nil {
+		dst.Keycloak = &KeycloakProvider{
+			Ca:                convertObjectRefFromHub(keycloak.Ca),
+			CredentialsSecret: convertObjectRefFromHub(keycloak.CredentialsSecret),
+			Insecure:          keycloak.Insecure,
+			Prune:             keycloak.Prune,
+			Groups:            keycloak.Groups,
+			LoginRealm:        keycloak.LoginRealm,
+			Realm:             keycloak.Realm,
+			Scope:             SyncScope(keycloak.Scope),
+			URL:               keycloak.URL,
+		}
+	}
+
+	if okta := src.Okta; okta != nil {
+		dst.Okta = &OktaProvider{
+			CredentialsSecret:    convertObjectRefFromHub(okta.CredentialsSecret),
+			Prune:                okta.Prune,
+			Groups:               okta.Groups,
+			URL:                  okta.URL,
+			AppId:                okta.AppId,
+			ExtractLoginUsername: okta.ExtractLoginUsername,
+			ProfileKey:           okta.ProfileKey,
+			GroupLimit:           okta.GroupLimit,
+		}
+	}
+
+	if isv := src.IbmSecurityVerify; isv != nil {
+		dst.IbmSecurityVerify = &IbmSecurityVerifyProvider{
+			CredentialsSecret: convertObjectRefFromHub(isv.CredentialsSecret),
+			TenantURL:         isv.TenantURL,
+		}
+		for _, group := range isv.Groups {
+			dst.IbmSecurityVerify.Groups = append(dst.IbmSecurityVerify.Groups, IsvGroupSpec{Name: group.Name, Id: group.Id})
+		}
+	}
+
+	return dst
+}
+
+// convertProviderBaseToHub folds the deprecated CaSecret field into Ca
+func convertProviderBaseToHub(ca, caSecret, credentialsSecret *ObjectRef, insecure, prune bool) v1beta1.ProviderBase {
+	if ca == nil {
+		ca = caSecret
+	}
+
+	return v1beta1.ProviderBase{
+		Ca:                convertObjectRefToHub(ca),
+		CredentialsSecret: convertObjectRefToHub(credentialsSecret),
+		Insecure:          insecure,
+		Prune:             prune,
+	}
+}
+
+func convertObjectRefToHub(src *ObjectRef) *v1beta1.ObjectRef {
+	if src == nil {
+		return nil
+	}
+
+	return &v1beta1.ObjectRef{
+		Key:       src.Key,
+		Name:      src.Name,
+		Namespace: src.Namespace,
+		Kind:      v1beta1.ObjectRefKind(src.Kind),
+	}
+}
+
+func convertObjectRefFromHub(src *v1beta1.ObjectRef) *ObjectRef {
+	if src == nil {
+		return nil
+	}
+
+	return &ObjectRef{
+		Key:       src.Key,
+		Name:      src.Name,
+		Namespace: src.Namespace,
+		Kind:      ObjectRefKind(src.Kind),
+	}
+}
+
+func stringPtrOrNil(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return ptr.To(value)
+}
+
+func stringSlicePtrOrNil(value []string) *[]string {
+	if value == nil {
+		return nil
+	}
+
+	return ptr.To(value)
+}
+
+// extractHubOnlyFields serializes the fields of the hub spec that are lost when converting to v1alpha1.
+// An empty string is returned when none of the fields are set.
+func extractHubOnlyFields(spec *v1beta1.GroupSyncSpec, status *v1beta1.GroupSyncStatus) (string, error) {
+	specFields, err := toFieldMap(spec)
+	if err != nil {
+		return "", err
+	}
+
+	hubOnly := map[string]interface{}{}
+
+	for _, field := range hubOnlySpecFields {
+		if value, ok := specFields[field]; ok {
+			hubOnly[field] = value
+		}
+	}
+
+	providers := map[string]interface{}{}
+
+	for _, provider := range spec.Providers {
+		providerFields, err := toFieldMap(provider)
+		if err != nil {
+			return "", err
+		}
+
+		hubOnlyProvider := map[string]interface{}{}
+
+		for _, field := range hubOnlyProviderCommonFields {
+			if value, ok := providerFields[field]; ok {
+				hubOnlyProvider[field] = value
+			}
+		}
+
+		for providerType, fields := range hubOnlyProviderFields {
+			typeFields, ok := providerFields[providerType].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			hubOnlyTypeFields := map[string]interface{}{}
+			for _, field := range fields {
+				if value, ok := typeFields[field]; ok {
+					hubOnlyTypeFields[field] = value
+				}
+			}
+
+			if len(hubOnlyTypeFields) > 0 {
+				hubOnlyProvider[providerType] = hubOnlyTypeFields
+			}
+		}
+
+		for _, providerType := range hubOnlyProviderTypes {
+			if typeFields, ok := providerFields[providerType]; ok {
+				hubOnlyProvider[providerType] = typeFields
+			}
+		}
+
+		if len(hubOnlyProvider) > 0 {
+			providers[provider.Name] = hubOnlyProvider
+		}
+	}
+
+	if len(providers) > 0 {
+		hubOnly["providers"] = providers
+	}
+
+	statusFields, err := toFieldMap(status)
+	if err != nil {
+		return "", err
+	}
+
+	hubOnlyStatus := map[string]interface{}{}
+	for _, field := range hubOnlyStatusFields {
+		if value, ok := statusFields[field]; ok {
+			hubOnlyStatus[field] = value
+		}
+	}
+
+	if len(hubOnlyStatus) > 0 {
+		hubOnly["status"] = hubOnlyStatus
+	}
+
+	if len(hubOnly) == 0 {
+		return "", nil
+	}
+
+	data, err := json.Marshal(hubOnly)
+
+	return string(data), err
+}
+
+// restoreHubOnlyFields merges the fields preserved by extractHubOnlyFields back into the hub spec and status.
+// Provider fields are only restored when a provider of the same name and type is still present.
+// Provider types only available in the hub are restored when a provider of the same name has no type.
+func restoreHubOnlyFields(spec *v1beta1.GroupSyncSpec, status *v1beta1.GroupSyncStatus, hubSpec string) error {
+	hubOnly := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(hubSpec), &hubOnly); err != nil {
+		return err
+	}
+
+	specFields, err := toFieldMap(spec)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range hubOnlySpecFields {
+		if value, ok := hubOnly[field]; ok {
+			specFields[field] = value
+		}
+	}
+
+	hubOnlyProviders, _ := hubOnly["providers"].(map[string]interface{})
+	providers, _ := specFields["providers"].([]interface{})
+
+	for _, provider := range providers {
+		providerFields, ok := provider.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		name, _ := providerFields["name"].(string)
+		hubOnlyProvider, ok := hubOnlyProviders[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for providerType, fields := range hubOnlyProviderFields {
+			typeFields, ok := providerFields[providerType].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			hubOnlyTypeFields, ok := hubOnlyProvider[providerType].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			for _, field := range fields {
+				if value, ok := hubOnlyTypeFields[field]; ok {
+					typeFields[field] = value
+				}
+			}
+		}
+
+		if len(providerFields) == 1 {
+			for _, providerType := range hubOnlyProviderTypes {
+				if typeFields, ok := hubOnlyProvider[providerType]; ok {
+					providerFields[providerType] = typeFields
+				}
+			}
+		}
+
+		for _, field := range hubOnlyProviderCommonFields {
+			if value, ok := hubOnlyProvider[field]; ok {
+				providerFields[field] = value
+			}
+		}
+	}
+
+	data, err := json.Marshal(specFields)
+	if err != nil {
+		return err
+	}
+
+	*spec = v1beta1.GroupSyncSpec{}
+	if err := json.Unmarshal(data, spec); err != nil {
+		return err
+	}
+
+	return restoreHubOnlyStatusFields(status, hubOnly)
+}
+
+// restoreHubOnlyStatusFields merges the status fields preserved by extractHubOnlyFields back into the hub status
+func restoreHubOnlyStatusFields(status *v1beta1.GroupSyncStatus, hubOnly map[string]interface{}) error {
+	hubOnlyStatus, ok := hubOnly["status"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	statusFields, err := toFieldMap(status)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range hubOnlyStatusFields {
+		if value, ok := hubOnlyStatus[field]; ok {
+			statusFields[field] = value
+		}
+	}
+
+	data, err := json.Marshal(statusFields)
+	if err != nil {
+		return err
+	}
+
+	*status = v1beta1.GroupSyncStatus{}
+
+	return json.Unmarshal(data, status)
+}
+
+func toFieldMap(obj interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{}
+	err = json.Unmarshal(data, &fields)
+
+	return fields, err
+}
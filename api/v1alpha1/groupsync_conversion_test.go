@@ -0,0 +1,176 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/redhat-cop/group-sync-operator/api/v1beta1"
+)
+
+func TestConvertToFoldsDeprecatedCaSecret(t *testing.T) {
+	src := &GroupSync{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap-groupsync", Namespace: "group-sync-operator"},
+		Spec: GroupSyncSpec{
+			Providers: []Provider{
+				{
+					Name: "ldap",
+					ProviderType: &ProviderType{
+						Ldap: &LdapProvider{
+							CaSecret:  &ObjectRef{Name: "ldap-ca", Namespace: "group-sync-operator", Kind: SecretMapObjectRefKind},
+							URL:       ptr.To("ldaps://ldap.example.com:636"),
+							Whitelist: &[]string{"cn=admins,dc=example,dc=com"},
+							Prune:     true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	dst := &v1beta1.GroupSync{}
+	assert.NoError(t, src.ConvertTo(dst))
+
+	ldap := dst.Spec.Providers[0].Ldap
+	assert.Equal(t, "ldap-ca", ldap.Ca.Name)
+	assert.Equal(t, "ldaps://ldap.example.com:636", ldap.URL)
+	assert.Equal(t, []string{"cn=admins,dc=example,dc=com"}, ldap.Whitelist)
+	assert.True(t, ldap.Prune)
+
+	roundTrip := &GroupSync{}
+	assert.NoError(t, roundTrip.ConvertFrom(dst))
+
+	assert.Equal(t, "ldap-ca", roundTrip.Spec.Providers[0].Ldap.Ca.Name)
+	assert.Nil(t, roundTrip.Spec.Providers[0].Ldap.CaSecret)
+	assert.Equal(t, src.Spec.Providers[0].Ldap.URL, roundTrip.Spec.Providers[0].Ldap.URL)
+	assert.NotContains(t, roundTrip.Annotations, HubSpecAnnotation)
+}
+
+func TestHubOnlyFieldsArePreserved(t *testing.T) {
+	hub := &v1beta1.GroupSync{
+		ObjectMeta: metav1.ObjectMeta{Name: "isv-groupsync", Namespace: "group-sync-operator"},
+		Spec: v1beta1.GroupSyncSpec{
+			Providers: []v1beta1.Provider{
+				{
+					Name: "isv",
+					ProviderType: &v1beta1.ProviderType{
+						IbmSecurityVerify: &v1beta1.IbmSecurityVerifyProvider{
+							ProviderBase: v1beta1.ProviderBase{
+								CredentialsSecret: &v1beta1.ObjectRef{Name: "isv-credentials", Namespace: "group-sync-operator"},
+								Insecure:          true,
+								Prune:             true,
+							},
+							Groups:    []v1beta1.IsvGroupSpec{{Name: "admins", Id: "1234"}},
+							TenantURL: "https://tenant.verify.ibm.com",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	spoke := &GroupSync{}
+	assert.NoError(t, spoke.ConvertFrom(hub))
+	assert.Contains(t, spoke.Annotations, HubSpecAnnotation)
+
+	restored := &v1beta1.GroupSync{}
+	assert.NoError(t, spoke.ConvertTo(restored))
+
+	assert.Equal(t, hub.Spec, restored.Spec)
+	assert.NotContains(t, restored.Annotations, HubSpecAnnotation)
+}
+
+func TestHubOnlyFieldsAreNotRestoredForRemovedProviders(t *testing.T) {
+	spoke := &GroupSync{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "okta-groupsync",
+			Namespace:   "group-sync-operator",
+			Annotations: map[string]string{HubSpecAnnotation: `{"providers":{"okta":{"okta":{"insecure":true}}}}`},
+		},
+		Spec: GroupSyncSpec{
+			Providers: []Provider{
+				{
+					Name: "other-okta",
+					ProviderType: &ProviderType{
+						Okta: &OktaProvider{URL: "https://example.okta.com", AppId: "app"},
+					},
+				},
+			},
+		},
+	}
+
+	dst := &v1beta1.GroupSync{}
+	assert.NoError(t, spoke.ConvertTo(dst))
+
+	assert.False(t, dst.Spec.Providers[0].Okta.Insecure)
+	assert.Nil(t, dst.Annotations)
+}
+
+func TestHubOnlyProviderTypesArePreserved(t *testing.T) {
+	hub := &v1beta1.GroupSync{
+		ObjectMeta: metav1.ObjectMeta{Name: "external-groupsync", Namespace: "group-sync-operator"},
+		Spec: v1beta1.GroupSyncSpec{
+			Providers: []v1beta1.Provider{
+				{
+					Name:   "hr",
+					Limits: &v1beta1.Limits{MaxMembers: 1000, MemberLimitPolicy: v1beta1.TruncateMemberLimitPolicy},
+					ProviderType: &v1beta1.ProviderType{
+						External: &v1beta1.ExternalProvider{
+							ProviderBase: v1beta1.ProviderBase{Prune: true},
+							Plugin:       "hr-groups",
+							Config:       map[string]string{"department": "engineering"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	spoke := &GroupSync{}
+	assert.NoError(t, spoke.ConvertFrom(hub))
+	assert.Equal(t, "hr", spoke.Spec.Providers[0].Name)
+
+	restored := &v1beta1.GroupSync{}
+	assert.NoError(t, spoke.ConvertTo(restored))
+
+	assert.Equal(t, hub.Spec, restored.Spec)
+}
+
+func TestHubOnlyStatusFieldsArePreserved(t *testing.T) {
+	now := metav1.Unix(1700000000, 0)
+	hub := &v1beta1.GroupSync{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-groupsync", Namespace: "group-sync-operator"},
+		Spec: v1beta1.GroupSyncSpec{
+			Providers: []v1beta1.Provider{
+				{
+					Name:         "github",
+					ProviderType: &v1beta1.ProviderType{GitHub: &v1beta1.GitHubProvider{Organization: "redhat-cop"}},
+				},
+			},
+		},
+		Status: v1beta1.GroupSyncStatus{
+			LastSyncSuccessTime: &now,
+			Providers: []v1beta1.ProviderStatus{
+				{
+					Name:                "github",
+					Conditions:          []metav1.Condition{{Type: "Healthy", Status: metav1.ConditionTrue, Reason: "SyncSucceeded", LastTransitionTime: now}},
+					LastSyncSuccessTime: &now,
+				},
+			},
+			LimitViolations: []v1beta1.LimitViolation{{Provider: "github", Group: "admins", Count: 120, Limit: 100, Policy: v1beta1.TruncateMemberLimitPolicy}},
+		},
+	}
+
+	spoke := &GroupSync{}
+	assert.NoError(t, spoke.ConvertFrom(hub))
+	assert.Contains(t, spoke.Annotations, HubSpecAnnotation)
+
+	restored := &v1beta1.GroupSync{}
+	assert.NoError(t, spoke.ConvertTo(restored))
+
+	assert.Equal(t, hub.Spec, restored.Spec)
+	assert.Equal(t, hub.Status, restored.Status)
+	assert.NotContains(t, restored.Annotations, HubSpecAnnotation)
+}
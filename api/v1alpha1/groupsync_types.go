@@ -51,6 +51,13 @@ type GroupSyncSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Exclude Invalid Group Names",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	// +kubebuilder:validation:Optional
 	ExcludeInvalidGroupNames bool `json:"excludeInvalidGroupNames,omitempty"`
+
+	// Targets represents remote clusters that synchronized groups are applied to in addition to the local cluster
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Targets"
+	// +kubebuilder:validation:Optional
+	Targets []Target `json:"targets,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
 }
 
 // GroupSyncStatus defines the observed state of GroupSync
@@ -68,6 +75,54 @@ type GroupSyncStatus struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Last Sync Success Time"
 	LastSyncSuccessTime *metav1.Time `json:"lastSyncSuccessTime,omitempty"`
+
+	// Targets represents the synchronization status of each remote cluster
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Targets"
+	Targets []TargetStatus `json:"targets,omitempty"`
+}
+
+// Target represents a remote cluster that groups are synchronized into
+// +k8s:openapi-gen=true
+type Target struct {
+	// Name represents the name of the target
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name of the Target"
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// KubeconfigSecret is a reference to a secret containing a kubeconfig used to communicate with the remote cluster. The key defaults to "kubeconfig"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret Containing the Kubeconfig",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
+	// +kubebuilder:validation:Required
+	KubeconfigSecret *ObjectRef `json:"kubeconfigSecret"`
+}
+
+// TargetStatus represents the synchronization status of a remote cluster
+// +k8s:openapi-gen=true
+type TargetStatus struct {
+	// Name represents the name of the target
+	Name string `json:"name"`
+
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Conditions",xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// LastSyncSuccessTime represents the time last synchronization to the target completed successfully
+	// +kubebuilder:validation:Optional
+	LastSyncSuccessTime *metav1.Time `json:"lastSyncSuccessTime,omitempty"`
+
+	// GroupsSynchronized represents the number of groups created or updated in the target during the last synchronization
+	// +kubebuilder:validation:Optional
+	GroupsSynchronized int `json:"groupsSynchronized,omitempty"`
+
+	// GroupsPruned represents the number of groups pruned from the target during the last synchronization
+	// +kubebuilder:validation:Optional
+	GroupsPruned int `json:"groupsPruned,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,1195 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	legacyconfigv1 "github.com/openshift/api/legacyconfig/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type SyncScope string
+type ObjectRefKind string
+type HTTPAuthenticationType string
+type HTTPPaginationType string
+type ExpressionLanguage string
+type MemberLimitPolicy string
+type GitHubTeamRole string
+type GitHubAPI string
+type GitHubUserMapping string
+type GitLabUserMapping string
+type UnmappedUserPolicy string
+type GitHubEnterpriseSource string
+type PatternSyntax string
+
+// GitHubTeamPrivacy represents the visibility of a GitHub team
+// +kubebuilder:validation:Enum=closed;secret
+type GitHubTeamPrivacy string
+
+// GitHubRepositoryPermission represents a level of access to a GitHub repository
+// +kubebuilder:validation:Enum=read;triage;write;maintain;admin
+type GitHubRepositoryPermission string
+
+// GitLabAccessLevel represents the role of a member of a GitLab group
+// +kubebuilder:validation:Enum=Guest;Reporter;Developer;Maintainer;Owner
+type GitLabAccessLevel string
+
+const (
+	OneSyncScope SyncScope = "one"
+	SubSyncScope SyncScope = "sub"
+
+	ConfigMapObjectRefKind ObjectRefKind = "ConfigMap"
+	SecretMapObjectRefKind ObjectRefKind = "Secret"
+
+	NoneHTTPAuthenticationType   HTTPAuthenticationType = "none"
+	BearerHTTPAuthenticationType HTTPAuthenticationType = "bearer"
+	BasicHTTPAuthenticationType  HTTPAuthenticationType = "basic"
+	OAuth2HTTPAuthenticationType HTTPAuthenticationType = "oauth2"
+
+	LinkHTTPPaginationType   HTTPPaginationType = "link"
+	CursorHTTPPaginationType HTTPPaginationType = "cursor"
+	OffsetHTTPPaginationType HTTPPaginationType = "offset"
+
+	JSONPathExpressionLanguage ExpressionLanguage = "JSONPath"
+	CELExpressionLanguage      ExpressionLanguage = "CEL"
+
+	SkipMemberLimitPolicy     MemberLimitPolicy = "Skip"
+	TruncateMemberLimitPolicy MemberLimitPolicy = "Truncate"
+	FailMemberLimitPolicy     MemberLimitPolicy = "Fail"
+
+	AllGitHubTeamRole        GitHubTeamRole = "all"
+	MemberGitHubTeamRole     GitHubTeamRole = "member"
+	MaintainerGitHubTeamRole GitHubTeamRole = "maintainer"
+
+	RESTGitHubAPI    GitHubAPI = "rest"
+	GraphQLGitHubAPI GitHubAPI = "graphql"
+
+	LoginGitHubUserMapping         GitHubUserMapping = "login"
+	ScimIdGitHubUserMapping        GitHubUserMapping = "scimId"
+	VerifiedEmailGitHubUserMapping GitHubUserMapping = "verifiedEmail"
+	EMUGitHubUserMapping           GitHubUserMapping = "emu"
+
+	UsernameGitLabUserMapping GitLabUserMapping = "username"
+	IdentityGitLabUserMapping GitLabUserMapping = "identity"
+
+	SkipUnmappedUserPolicy   UnmappedUserPolicy = "Skip"
+	RetainUnmappedUserPolicy UnmappedUserPolicy = "Retain"
+	FailUnmappedUserPolicy   UnmappedUserPolicy = "Fail"
+
+	SCIMGroupsGitHubEnterpriseSource GitHubEnterpriseSource = "scimGroups"
+	TeamsGitHubEnterpriseSource      GitHubEnterpriseSource = "teams"
+
+	GlobPatternSyntax  PatternSyntax = "Glob"
+	RegexPatternSyntax PatternSyntax = "Regex"
+
+	ClosedGitHubTeamPrivacy GitHubTeamPrivacy = "closed"
+	SecretGitHubTeamPrivacy GitHubTeamPrivacy = "secret"
+
+	ReadGitHubRepositoryPermission     GitHubRepositoryPermission = "read"
+	TriageGitHubRepositoryPermission   GitHubRepositoryPermission = "triage"
+	WriteGitHubRepositoryPermission    GitHubRepositoryPermission = "write"
+	MaintainGitHubRepositoryPermission GitHubRepositoryPermission = "maintain"
+	AdminGitHubRepositoryPermission    GitHubRepositoryPermission = "admin"
+
+	GuestGitLabAccessLevel      GitLabAccessLevel = "Guest"
+	ReporterGitLabAccessLevel   GitLabAccessLevel = "Reporter"
+	DeveloperGitLabAccessLevel  GitLabAccessLevel = "Developer"
+	MaintainerGitLabAccessLevel GitLabAccessLevel = "Maintainer"
+	OwnerGitLabAccessLevel      GitLabAccessLevel = "Owner"
+)
+
+// GroupSyncSpec defines the desired state of GroupSync
+// +k8s:openapi-gen=true
+type GroupSyncSpec struct {
+
+	// List of Providers that can be mounted by containers belonging to the pod.
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Providers"
+	Providers []Provider `json:"providers,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name" protobuf:"bytes,1,rep,name=providers"`
+
+	// Schedule represents a cron based configuration for synchronization
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Schedule",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// ExcludeInvalidGroupNames excludes Groups with names that are not RFC 1035 compliant.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Exclude Invalid Group Names",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	ExcludeInvalidGroupNames bool `json:"excludeInvalidGroupNames,omitempty"`
+
+	// Targets represents remote clusters that synchronized groups are applied to in addition to the local cluster
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Targets"
+	// +kubebuilder:validation:Optional
+	Targets []Target `json:"targets,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+
+	// Composites represents groups whose members are derived from the groups synchronized by providers
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Composites"
+	// +kubebuilder:validation:Optional
+	Composites []CompositeGroup `json:"composites,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+}
+
+// GroupSyncStatus defines the observed state of GroupSync
+// +k8s:openapi-gen=true
+type GroupSyncStatus struct {
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Conditions",xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// LastSyncSuccessTime represents the time last synchronization completed successfully
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Last Sync Success Time"
+	LastSyncSuccessTime *metav1.Time `json:"lastSyncSuccessTime,omitempty"`
+
+	// Targets represents the synchronization status of each remote cluster
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Targets"
+	Targets []TargetStatus `json:"targets,omitempty"`
+
+	// LimitViolations represents the providers and groups that exceeded their limits during the last synchronization
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Limit Violations"
+	LimitViolations []LimitViolation `json:"limitViolations,omitempty"`
+
+	// Providers represents the health of each provider
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Providers"
+	Providers []ProviderStatus `json:"providers,omitempty"`
+}
+
+// ProviderStatus represents the health of a provider based on the most recent synchronization or connectivity probe
+// +k8s:openapi-gen=true
+type ProviderStatus struct {
+	// Name represents the name of the provider
+	Name string `json:"name"`
+
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Conditions",xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// LastSyncSuccessTime represents the time groups were last retrieved from the provider successfully
+	// +kubebuilder:validation:Optional
+	LastSyncSuccessTime *metav1.Time `json:"lastSyncSuccessTime,omitempty"`
+
+	// LastProbeTime represents the time connectivity to the provider was last probed
+	// +kubebuilder:validation:Optional
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+}
+
+// LimitViolation represents a provider or group exceeding the limits of a provider
+// +k8s:openapi-gen=true
+type LimitViolation struct {
+	// Provider represents the name of the provider
+	Provider string `json:"provider"`
+
+	// Group represents the name of the group exceeding the maximum number of members. Not set when the provider exceeded the maximum number of groups
+	// +kubebuilder:validation:Optional
+	Group string `json:"group,omitempty"`
+
+	// Count represents the number of members or groups returned by the provider
+	Count int `json:"count"`
+
+	// Limit represents the configured maximum number of members or groups
+	Limit int `json:"limit"`
+
+	// Policy represents the action taken as a result of the violation
+	// +kubebuilder:validation:Optional
+	Policy MemberLimitPolicy `json:"policy,omitempty"`
+}
+
+// Target represents a remote cluster that groups are synchronized into
+// +k8s:openapi-gen=true
+type Target struct {
+	// Name represents the name of the target
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name of the Target"
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// KubeconfigSecret is a reference to a secret containing a kubeconfig used to communicate with the remote cluster. The key defaults to "kubeconfig"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret Containing the Kubeconfig",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
+	// +kubebuilder:validation:Required
+	KubeconfigSecret *ObjectRef `json:"kubeconfigSecret"`
+}
+
+// CompositeGroup represents a group whose members are the result of set operations on the groups synchronized by providers
+// +k8s:openapi-gen=true
+type CompositeGroup struct {
+	// Name represents the name of the resulting group. Must not match the name of a provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name of the Group"
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Include represents the groups whose members are combined into the group
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Included Groups"
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Include []CompositeGroupRef `json:"include"`
+
+	// Intersect represents groups that each member of the group must also belong to
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Intersected Groups"
+	// +kubebuilder:validation:Optional
+	Intersect []CompositeGroupRef `json:"intersect,omitempty"`
+
+	// Exclude represents groups whose members are removed from the group
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Excluded Groups"
+	// +kubebuilder:validation:Optional
+	Exclude []CompositeGroupRef `json:"exclude,omitempty"`
+
+	// Prune represents whether the group should be removed when it is renamed
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prune",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	Prune bool `json:"prune,omitempty"`
+}
+
+// CompositeGroupRef references a group synchronized by a provider
+// +k8s:openapi-gen=true
+type CompositeGroupRef struct {
+	// Provider represents the name of the provider synchronizing the group
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Provider",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Provider string `json:"provider"`
+
+	// Group represents the name of the group as synchronized by the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Group string `json:"group"`
+}
+
+// TargetStatus represents the synchronization status of a remote cluster
+// +k8s:openapi-gen=true
+type TargetStatus struct {
+	// Name represents the name of the target
+	Name string `json:"name"`
+
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Conditions",xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// LastSyncSuccessTime represents the time last synchronization to the target completed successfully
+	// +kubebuilder:validation:Optional
+	LastSyncSuccessTime *metav1.Time `json:"lastSyncSuccessTime,omitempty"`
+
+	// GroupsSynchronized represents the number of groups created or updated in the target during the last synchronization
+	// +kubebuilder:validation:Optional
+	GroupsSynchronized int `json:"groupsSynchronized,omitempty"`
+
+	// GroupsPruned represents the number of groups pruned from the target during the last synchronization
+	// +kubebuilder:validation:Optional
+	GroupsPruned int `json:"groupsPruned,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// GroupSync is the Schema for the groupsyncs API
+// +kubebuilder:storageversion
+// +operator-sdk:csv:customresourcedefinitions:displayName="Group Sync"
+// +kubebuilder:resource:path=groupsyncs,scope=Namespaced
+// +k8s:openapi-gen=true
+type GroupSync struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GroupSyncSpec   `json:"spec,omitempty"`
+	Status GroupSyncStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GroupSyncList contains a list of GroupSync
+// +k8s:openapi-gen=true
+type GroupSyncList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GroupSync `json:"items"`
+}
+
+// Provider represents the container for a single provider
+// +k8s:openapi-gen=true
+type Provider struct {
+	// Name represents the name of the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name of the Provider"
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Limits represents the maximum size of the groups synchronized by the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Limits"
+	// +kubebuilder:validation:Optional
+	Limits *Limits `json:"limits,omitempty"`
+
+	*ProviderType `json:",inline"`
+}
+
+// Limits represents the maximum size of the groups synchronized by a provider
+// +k8s:openapi-gen=true
+type Limits struct {
+	// MaxMembers represents the maximum number of members of each group
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Maximum Members",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxMembers int `json:"maxMembers,omitempty"`
+
+	// MemberLimitPolicy represents the action taken when a group exceeds the maximum number of members. Skip leaves the group unchanged, Truncate synchronizes the first members in alphabetical order and Fail fails the synchronization of the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Member Limit Policy",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Skip","urn:alm:descriptor:com.tectonic.ui:select:Truncate","urn:alm:descriptor:com.tectonic.ui:select:Fail"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Skip;Truncate;Fail
+	// +kubebuilder:default="Skip"
+	MemberLimitPolicy MemberLimitPolicy `json:"memberLimitPolicy,omitempty"`
+
+	// MaxGroups represents the maximum number of groups synchronized by the provider. The synchronization of the provider fails when exceeded
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Maximum Groups",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxGroups int `json:"maxGroups,omitempty"`
+}
+
+// ProviderType represents the provider to synchronize against
+// +k8s:openapi-gen=true
+type ProviderType struct {
+	// Azure represents the Azure provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Azure Provider"
+	// +kubebuilder:validation:Optional
+	Azure *AzureProvider `json:"azure,omitempty"`
+
+	// GitHub represents the GitHub provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="GitHub Provider"
+	// +kubebuilder:validation:Optional
+	GitHub *GitHubProvider `json:"github,omitempty"`
+
+	// GitLab represents the GitLab provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="GitLab Provider"
+	// +kubebuilder:validation:Optional
+	GitLab *GitLabProvider `json:"gitlab,omitempty"`
+
+	// Ldap represents the LDAP provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="LDAP Provider"
+	// +kubebuilder:validation:Optional
+	Ldap *LdapProvider `json:"ldap,omitempty"`
+
+	// Keycloak represents the Keycloak provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Keycloak Provider"
+	// +kubebuilder:validation:Optional
+	Keycloak *KeycloakProvider `json:"keycloak,omitempty"`
+
+	// Okta represents the Okta provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Okta Provider"
+	// +kubebuilder:validation:Optional
+	Okta *OktaProvider `json:"okta,omitempty"`
+
+	// IbmSecurityVerify represents the IBM Security Verify provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="IBM Security Verify"
+	// +kubebuilder:validation:Optional
+	IbmSecurityVerify *IbmSecurityVerifyProvider `json:"ibmsecurityverify,omitempty"`
+
+	// External represents a provider implemented by an external plugin
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="External Provider"
+	// +kubebuilder:validation:Optional
+	External *ExternalProvider `json:"external,omitempty"`
+
+	// HTTP represents the HTTP provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="HTTP Provider"
+	// +kubebuilder:validation:Optional
+	HTTP *HTTPProvider `json:"http,omitempty"`
+
+	// Static represents groups defined within the GroupSync or a ConfigMap or Secret
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Static Provider"
+	// +kubebuilder:validation:Optional
+	Static *StaticProvider `json:"static,omitempty"`
+
+	// File represents groups defined in a file mounted within the operator
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="File Provider"
+	// +kubebuilder:validation:Optional
+	File *FileProvider `json:"file,omitempty"`
+}
+
+// ProviderBase represents the configuration common to all providers
+// +k8s:openapi-gen=true
+type ProviderBase struct {
+	// Ca is a reference to a Secret or ConfigMap containing a CA certificate to communicate to the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Containing the CA Certificate",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
+	// +kubebuilder:validation:Optional
+	Ca *ObjectRef `json:"ca,omitempty"`
+
+	// CredentialsSecret is a reference to a secret containing authentication details for the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret Containing the Credentials",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
+	// +kubebuilder:validation:Optional
+	CredentialsSecret *ObjectRef `json:"credentialsSecret,omitempty"`
+
+	// Insecure specifies whether to allow for unverified certificates to be used when communicating to the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ignore SSL Verification",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// Prune Whether to prune groups that are no longer in the provider. Default is false
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prune",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	Prune bool `json:"prune,omitempty"`
+}
+
+// HTTPClientOptions represents the connection settings for providers communicating over HTTP
+// +k8s:openapi-gen=true
+type HTTPClientOptions struct {
+	// ClientCertificate is a reference to a secret containing a client certificate and key used to authenticate to the provider. The secret must contain the "tls.crt" and "tls.key" keys
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret Containing the Client Certificate",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
+	// +kubebuilder:validation:Optional
+	ClientCertificate *ObjectRef `json:"clientCertificate,omitempty"`
+
+	// MinTLSVersion is the minimum TLS version accepted when communicating to the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Minimum TLS Version",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum="1.0";"1.1";"1.2";"1.3"
+	MinTLSVersion string `json:"minTLSVersion,omitempty"`
+
+	// Timeout is the time limit for requests made to the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Request Timeout",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Proxy represents the proxy used to communicate to the provider instead of the proxy configured in the environment
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Proxy"
+	// +kubebuilder:validation:Optional
+	Proxy *Proxy `json:"proxy,omitempty"`
+}
+
+// Proxy represents an HTTP or HTTPS proxy
+// +k8s:openapi-gen=true
+type Proxy struct {
+	// URL is the location of the proxy
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Proxy URL",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	URL string `json:"url"`
+
+	// CredentialsSecret is a reference to a secret containing the username and password used to authenticate to the proxy
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret Containing the Proxy Credentials",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
+	// +kubebuilder:validation:Optional
+	CredentialsSecret *ObjectRef `json:"credentialsSecret,omitempty"`
+
+	// NoProxy is a list of hosts, domains, IP addresses or CIDR ranges that are communicated with directly
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Hosts Excluded from the Proxy",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	NoProxy []string `json:"noProxy,omitempty"`
+}
+
+// GitHubEnterprise represents the synchronization of groups defined at the level of a GitHub enterprise
+// +k8s:openapi-gen=true
+type GitHubEnterprise struct {
+	// Slug represents the slug of the enterprise
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enterprise Slug",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Slug string `json:"slug"`
+
+	// Source represents the groups synchronized from the enterprise. scimGroups synchronizes the groups provisioned using
+	// the enterprise SCIM API and teams synchronizes enterprise teams
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Source"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=scimGroups;teams
+	// +kubebuilder:default=scimGroups
+	Source GitHubEnterpriseSource `json:"source,omitempty"`
+}
+
+// GitHubRepositories represents the synchronization of a group for each permission level of the repositories of an organization
+// +k8s:openapi-gen=true
+type GitHubRepositories struct {
+	// Names represents the names of the repositories to synchronize
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Repository Names",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Names []string `json:"names,omitempty"`
+
+	// Patterns represents patterns selecting the repositories to synchronize by name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Repository Patterns"
+	// +kubebuilder:validation:Optional
+	Patterns *PatternFilter `json:"patterns,omitempty"`
+
+	// Permissions represents the permission levels a group is synchronized for. Each group contains the users with at least the permission level. Every permission level is synchronized when not set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Permissions"
+	// +kubebuilder:validation:Optional
+	Permissions []GitHubRepositoryPermission `json:"permissions,omitempty"`
+
+	// NameTemplate represents the name of each group. The {organization}, {repository} and {permission} placeholders are replaced by the organization, repository and permission level of the group
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name Template",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	NameTemplate string `json:"nameTemplate,omitempty"`
+}
+
+// Hierarchy represents how nested groups reported by a provider are synchronized
+// +k8s:openapi-gen=true
+type Hierarchy struct {
+	// Flatten represents whether the members of nested groups are added to each of their parent groups
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Flatten",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	Flatten bool `json:"flatten,omitempty"`
+
+	// MaxDepth represents the number of levels of nested groups whose members are added to a parent group. All levels are included when not set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Maximum Depth",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MaxDepth int `json:"maxDepth,omitempty"`
+}
+
+// GroupFilter represents the filters limiting the groups that are synchronized from a provider
+// +k8s:openapi-gen=true
+type GroupFilter struct {
+	// Groups represents a filtered list of groups to synchronize
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Groups to Synchronize",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Groups []string `json:"groups,omitempty"`
+
+	// GroupPatterns represents patterns selecting the groups to synchronize by name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group Patterns"
+	// +kubebuilder:validation:Optional
+	GroupPatterns *PatternFilter `json:"groupPatterns,omitempty"`
+}
+
+// PatternFilter represents patterns including and excluding groups by name. A group is synchronized when it is either
+// listed explicitly or matches an include pattern, and does not match an exclude pattern
+// +k8s:openapi-gen=true
+type PatternFilter struct {
+	// Include represents patterns selecting groups to synchronize. All groups are selected when neither patterns nor names are provided
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Include",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Include []string `json:"include,omitempty"`
+
+	// Exclude represents patterns selecting groups that are not synchronized
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Exclude",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Exclude []string `json:"exclude,omitempty"`
+
+	// Syntax represents the syntax of the patterns. Glob patterns support the * and ? wildcards while Regex patterns must match the entire name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Syntax"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Glob;Regex
+	// +kubebuilder:default=Glob
+	Syntax PatternSyntax `json:"syntax,omitempty"`
+}
+
+// KeycloakProvider represents integration with Keycloak
+// +k8s:openapi-gen=true
+type KeycloakProvider struct {
+	ProviderBase      `json:",inline"`
+	HTTPClientOptions `json:",inline"`
+	GroupFilter       `json:",inline"`
+
+	// LoginRealm is the Keycloak realm to authenticate against
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Realm to Login Against"
+	// +kubebuilder:validation:Optional
+	LoginRealm string `json:"loginRealm,omitempty"`
+
+	// Realm is the realm containing the groups to synchronize against
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Realm to Synchronize",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Realm string `json:"realm"`
+
+	// Scope represents the depth for which groups will be synchronized
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Scope to synchronize against"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=one;sub
+	Scope SyncScope `json:"scope,omitempty"`
+
+	// MembershipExpiryAttribute represents the user attribute containing the RFC 3339 time at which the group memberships of the user expire
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Membership Expiry Attribute",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	MembershipExpiryAttribute string `json:"membershipExpiryAttribute,omitempty"`
+
+	// URL is the location of the Keycloak server
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Keycloak URL",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	URL string `json:"url"`
+}
+
+// GitHubProvider represents integration with GitHub
+// +k8s:openapi-gen=true
+type GitHubProvider struct {
+	ProviderBase      `json:",inline"`
+	HTTPClientOptions `json:",inline"`
+
+	// Organization represents the location to source teams to synchronize
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Organization to Synchronize",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Organization string `json:"organization,omitempty"`
+
+	// Organizations represents additional organizations to source teams to synchronize
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Organizations to Synchronize",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Organizations []string `json:"organizations,omitempty"`
+
+	// Enterprise represents the synchronization of groups defined at the level of an enterprise rather than an organization
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enterprise"
+	// +kubebuilder:validation:Optional
+	Enterprise *GitHubEnterprise `json:"enterprise,omitempty"`
+
+	// Repositories represents the synchronization of the collaborators of repositories rather than teams
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Repositories"
+	// +kubebuilder:validation:Optional
+	Repositories *GitHubRepositories `json:"repositories,omitempty"`
+
+	// AllOrganizations represents whether to synchronize every organization the GitHub App is installed in
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Synchronize All Organizations",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	AllOrganizations bool `json:"allOrganizations,omitempty"`
+
+	// Teams represents a filtered list of teams to synchronize
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Teams to Synchronize",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Teams []string `json:"teams,omitempty"`
+
+	// TeamPatterns represents patterns selecting the teams to synchronize by name or slug
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Team Patterns"
+	// +kubebuilder:validation:Optional
+	TeamPatterns *PatternFilter `json:"teamPatterns,omitempty"`
+
+	// TeamPrivacy represents the privacy of the teams to synchronize. Teams are synchronized regardless of their privacy when not set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Team Privacy"
+	// +kubebuilder:validation:Optional
+	TeamPrivacy []GitHubTeamPrivacy `json:"teamPrivacy,omitempty"`
+
+	// Scope represents the depth for which teams will be synchronized. The one scope only includes the direct members of
+	// each team and requires the graphql API. The sub scope selects the teams nested within each team selected by Teams.
+	// The members of nested teams are included unless using the one scope
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Scope to synchronize against"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=one;sub
+	Scope SyncScope `json:"scope,omitempty"`
+
+	// TeamMemberRole represents the role of the team members to synchronize
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Team Member Role"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=all;member;maintainer
+	TeamMemberRole GitHubTeamRole `json:"teamMemberRole,omitempty"`
+
+	// MaintainerGroups represents whether a group named <team>-maintainers containing the maintainers of each team is synchronized
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Maintainer Groups",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	MaintainerGroups bool `json:"maintainerGroups,omitempty"`
+
+	// OwnersGroup represents the name of a group containing the owners of the organization. No group is synchronized when not set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Owners Group",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	OwnersGroup string `json:"ownersGroup,omitempty"`
+
+	// Hierarchy represents how nested teams are synchronized
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Hierarchy"
+	// +kubebuilder:validation:Optional
+	Hierarchy *Hierarchy `json:"hierarchy,omitempty"`
+
+	// Map users by SCIM Id. This will usually match your IDP id, like UPN when using AAD.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Map users by SCIM-ID",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	MapByScimId bool `json:"mapByScimId,omitempty"`
+
+	// UserMapping represents how GitHub logins are mapped to user names. scimId maps to the SCIM identity of the user,
+	// verifiedEmail maps to the email address of the user in a verified domain of the organization and emu removes the
+	// shortcode suffix of Enterprise Managed Users
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="User Mapping"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=login;scimId;verifiedEmail;emu
+	UserMapping GitHubUserMapping `json:"userMapping,omitempty"`
+
+	// VerifiedEmailDomain represents the verified domain of the email address used when mapping users by verifiedEmail.
+	// The first verified email address of the user is used when not set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Verified Email Domain",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	VerifiedEmailDomain string `json:"verifiedEmailDomain,omitempty"`
+
+	// EMUShortcode represents the shortcode of the enterprise removed from the logins of Enterprise Managed Users when
+	// mapping users by emu. The suffix following the last underscore is removed when not set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="EMU Shortcode",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	EMUShortcode string `json:"emuShortcode,omitempty"`
+
+	// UnmappedUserPolicy represents the action taken when a user cannot be mapped. Skip omits the user from the group,
+	// Retain uses the GitHub login and Fail fails the synchronization of the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Unmapped User Policy"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Skip;Retain;Fail
+	UnmappedUserPolicy UnmappedUserPolicy `json:"unmappedUserPolicy,omitempty"`
+
+	// API represents the GitHub API used to retrieve teams and their members. The graphql API retrieves teams along with
+	// their members in batches rather than querying the members of each team individually
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="GitHub API"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=rest;graphql
+	API GitHubAPI `json:"api,omitempty"`
+
+	// URL is the location of the GitHub server
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="GitHub URL",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	// +kubebuilder:default="https://api.github.com/"
+	URL string `json:"url,omitempty"`
+
+	// V4URL is the location of the GitHub server graphql endpoint.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="GitHub v4URL (graphql)",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="https://api.github.com/graphql"
+	V4URL string `json:"v4url,omitempty"`
+}
+
+// GitLabProvider represents integration with GitLab
+// +k8s:openapi-gen=true
+type GitLabProvider struct {
+	ProviderBase      `json:",inline"`
+	HTTPClientOptions `json:",inline"`
+	GroupFilter       `json:",inline"`
+
+	// Scope represents the depth for which groups will be synchronized
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Scope to synchronize against"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=one;sub
+	Scope SyncScope `json:"scope,omitempty"`
+
+	// Hierarchy represents how nested subgroups are synchronized
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Hierarchy"
+	// +kubebuilder:validation:Optional
+	Hierarchy *Hierarchy `json:"hierarchy,omitempty"`
+
+	// MinAccessLevel represents the minimum access level of the members that are synchronized. Members are synchronized regardless of their access level when not set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Minimum Access Level"
+	// +kubebuilder:validation:Optional
+	MinAccessLevel GitLabAccessLevel `json:"minAccessLevel,omitempty"`
+
+	// AccessLevelGroups represents the access levels for which an additional <group>-<level>s group is synchronized. Each group contains the members with the access level or higher,
+	// so that the <group>-maintainers group also contains the owners of the group. Access levels must not be below MinAccessLevel
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Access Level Groups"
+	// +kubebuilder:validation:Optional
+	AccessLevelGroups []GitLabAccessLevel `json:"accessLevelGroups,omitempty"`
+
+	// MemberStates represents the states of the members to synchronize, such as active, blocked or deactivated. Members are synchronized regardless of their state when not set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Member States",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	MemberStates []string `json:"memberStates,omitempty"`
+
+	// ExcludeBots represents whether bot users, including service accounts and the users of access tokens, are omitted from groups
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Exclude Bots",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	ExcludeBots bool `json:"excludeBots,omitempty"`
+
+	// UserMapping represents how GitLab users are mapped to user names. identity maps to the external UID of the SAML or
+	// LDAP identity of the user
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="User Mapping"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=username;identity
+	UserMapping GitLabUserMapping `json:"userMapping,omitempty"`
+
+	// IdentityProvider represents the name of the provider of the identity used by the identity mapping, such as saml or ldapmain. The first identity of the user is used when not set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Identity Provider",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	IdentityProvider string `json:"identityProvider,omitempty"`
+
+	// UnmappedUserPolicy represents the action taken when a user cannot be mapped. Skip omits the user from the group,
+	// Retain uses the GitLab username and Fail fails the synchronization of the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Unmapped User Policy"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Skip;Retain;Fail
+	UnmappedUserPolicy UnmappedUserPolicy `json:"unmappedUserPolicy,omitempty"`
+
+	// URL is the location of the GitLab server
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="GitLab URL",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="https://gitlab.com"
+	URL string `json:"url,omitempty"`
+}
+
+// LdapProvider represents integration with an LDAP server
+// +k8s:openapi-gen=true
+type LdapProvider struct {
+	ProviderBase `json:",inline"`
+
+	/// LDAPGroupUIDToOpenShiftGroupNameMapping is an optional direct mapping of LDAP group UIDs to OpenShift group names
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="LDAP group UID's to OpenShift group name mapping"
+	// +kubebuilder:validation:Optional
+	LDAPGroupUIDToOpenShiftGroupNameMapping map[string]string `json:"groupUIDNameMapping"`
+
+	// RFC2307Config represents the configuration for a RFC2307 schema
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="RFC2307 configuration"
+	// +kubebuilder:validation:Optional
+	// +optional
+	RFC2307Config *legacyconfigv1.RFC2307Config `json:"rfc2307,omitempty"`
+
+	// ActiveDirectoryConfig represents the configuration for Active Directory
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Active Directory configuration"
+	// +kubebuilder:validation:Optional
+	ActiveDirectoryConfig *legacyconfigv1.ActiveDirectoryConfig `json:"activeDirectory,omitempty"`
+
+	// ActiveDirectoryConfig represents the configuration for Augmented Active Directory
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Augmented Active Directory configuration"
+	// +kubebuilder:validation:Optional
+	AugmentedActiveDirectoryConfig *legacyconfigv1.AugmentedActiveDirectoryConfig `json:"augmentedActiveDirectory,omitempty"`
+
+	// URL is the location of the LDAP Server
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="LDAP URL",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	URL string `json:"url"`
+
+	// Whitelist represents a list of groups to synchronize
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Whitelisted groups to synchronize",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Whitelist []string `json:"whitelist,omitempty"`
+
+	// Blacklist represents a list of groups to not synchronize
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Blacklisted groups to not synchronize",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Blacklist []string `json:"blacklist,omitempty"`
+}
+
+// AzureProvider represents integration with Azure
+// +k8s:openapi-gen=true
+type AzureProvider struct {
+	ProviderBase      `json:",inline"`
+	HTTPClientOptions `json:",inline"`
+	GroupFilter       `json:",inline"`
+
+	// BaseGroups allows for a set of groups to be specified to start searching from instead of searching all groups in the directory
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Base Groups",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	BaseGroups []string `json:"baseGroups,omitempty"`
+
+	// Filter allows for limiting the results from the groups response using the Filter feature of the Azure Graph API
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Filter",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Filter string `json:"filter,omitempty"`
+
+	// ClientFilter is a CEL expression for client-side filtering of groups after retrieval from Azure.
+	// The expression evaluates against a 'group' object with fields derived from the Azure Group object.
+	// String fields absent from the API response default to "", bool fields to false, int fields to 0.
+	// Supported fields: displayName, mailNickname, id, securityEnabled, mailEnabled, description,
+	// groupTypes, createdDateTime, visibility, membershipRule, onPremisesSyncEnabled, mail.
+	// Example: 'group.mailNickname == group.displayName' filters to groups where these fields match.
+	// Example: 'group.securityEnabled && group.description == ""' filters to security groups without descriptions.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Client Filter (CEL Expression)",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	ClientFilter string `json:"clientFilter,omitempty"`
+
+	// AuthorityHost is the location of the Azure Active Directory endpoint
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Azure URL",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	AuthorityHost string `json:"authorityHost,omitempty"`
+
+	// UserNameAttributes are the fields to consider on the User object containing the username
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Azure UserName Attributes",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	UserNameAttributes []string `json:"userNameAttributes,omitempty"`
+
+	// PrivilegedAccessExpiry represents whether the end time of active Privileged Identity Management (PIM) assignments
+	// to groups is recorded as the time at which the membership expires
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Privileged Access Expiry",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	PrivilegedAccessExpiry bool `json:"privilegedAccessExpiry,omitempty"`
+}
+
+// OktaProvider represents integration with Okta
+// +k8s:openapi-gen=true
+type OktaProvider struct {
+	ProviderBase      `json:",inline"`
+	HTTPClientOptions `json:",inline"`
+	GroupFilter       `json:",inline"`
+
+	// URL is the location of the Okta domain server
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Okta URL",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	URL string `json:"url"`
+
+	// AppId is the id of the application we are syncing groups for
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="App ID",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	AppId string `json:"appId"`
+
+	// ExtractLoginUsername is true if Okta username's are defaulted to emails and you would like the username only
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Extract Login Username",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	ExtractLoginUsername bool `json:"extractLoginUsername"`
+
+	// ProfileKey the attribute from Okta you would like to use as the user identifier.  Default is "login"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Profile Key",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	ProfileKey string `json:"profileKey"`
+
+	// GroupLimit is the maximum number of groups that are requested from OKTA per request.  Multiple requests will be made using pagination if you have more groups than this limit. Default is "1000"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group Limit",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	// +kubebuilder:validation:Optional
+	GroupLimit int `json:"groupLimit"`
+}
+
+// IbmSecurityVerifyProvider represents integration with IBM Security Verify
+// +k8s:openapi-gen=true
+type IbmSecurityVerifyProvider struct {
+	ProviderBase      `json:",inline"`
+	HTTPClientOptions `json:",inline"`
+
+	// Groups is the list of ISV groups to synchronize
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Groups to Synchronize",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Groups []IsvGroupSpec `json:"groups,omitempty"`
+
+	// TenantURL is the location of the IBM Security Verify tenant
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tenant URL",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	TenantURL string `json:"tenantUrl"`
+}
+
+// +k8s:openapi-gen=true
+type IsvGroupSpec struct {
+	// The display name of the group as defined in IBM Security Verify
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Name string `json:"name,omitempty"`
+	// The ID of the group as defined in IBM Security Verify. This value can be found by using the API.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Id",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Id string `json:"id,omitempty"`
+}
+
+// ExternalProvider represents integration with an external plugin executed by the operator
+// +k8s:openapi-gen=true
+type ExternalProvider struct {
+	ProviderBase `json:",inline"`
+	GroupFilter  `json:",inline"`
+
+	// Plugin is the name of the plugin executable located in the plugin directory of the operator
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Plugin",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9._-]+$`
+	Plugin string `json:"plugin"`
+
+	// Args are the arguments provided to the plugin
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Arguments",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Args []string `json:"args,omitempty"`
+
+	// Config represents configuration values provided to the plugin
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Configuration"
+	// +kubebuilder:validation:Optional
+	Config map[string]string `json:"config,omitempty"`
+
+	// Timeout is the time limit for the plugin to return the groups. Default is 1 minute
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Timeout",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// HTTPProvider represents integration with a REST API returning groups as JSON
+// +k8s:openapi-gen=true
+type HTTPProvider struct {
+	ProviderBase      `json:",inline"`
+	HTTPClientOptions `json:",inline"`
+	GroupFilter       `json:",inline"`
+
+	// URL is the location of the endpoint returning the groups
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="URL",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	URL string `json:"url"`
+
+	// Authentication is the method used to authenticate to the endpoint using the credentialsSecret. Default is "none"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Authentication",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:none","urn:alm:descriptor:com.tectonic.ui:select:bearer","urn:alm:descriptor:com.tectonic.ui:select:basic","urn:alm:descriptor:com.tectonic.ui:select:oauth2"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=none;bearer;basic;oauth2
+	Authentication HTTPAuthenticationType `json:"authentication,omitempty"`
+
+	// TokenURL is the location of the token endpoint when using the oauth2 authentication method
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Token URL",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	TokenURL string `json:"tokenUrl,omitempty"`
+
+	// Scopes are requested from the token endpoint when using the oauth2 authentication method
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Scopes",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Scopes []string `json:"scopes,omitempty"`
+
+	// Pagination represents how additional pages of groups are requested
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pagination"
+	// +kubebuilder:validation:Optional
+	Pagination *HTTPPagination `json:"pagination,omitempty"`
+
+	// Mapping represents the expressions extracting groups from the response
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Mapping"
+	// +kubebuilder:validation:Required
+	Mapping HTTPMapping `json:"mapping"`
+}
+
+// HTTPPagination represents how additional pages are requested from an endpoint
+// +k8s:openapi-gen=true
+type HTTPPagination struct {
+	// Type is the pagination style of the endpoint
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Type",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:link","urn:alm:descriptor:com.tectonic.ui:select:cursor","urn:alm:descriptor:com.tectonic.ui:select:offset"}
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=link;cursor;offset
+	Type HTTPPaginationType `json:"type"`
+
+	// Cursor is an expression extracting the cursor of the next page from the response when using cursor pagination
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cursor",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Cursor string `json:"cursor,omitempty"`
+
+	// Parameter is the query parameter containing the cursor or offset. Defaults to "cursor" or "offset"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Parameter",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Parameter string `json:"parameter,omitempty"`
+
+	// LimitParameter is the query parameter containing the number of groups requested per page when using offset pagination. Default is "limit"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Limit Parameter",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	LimitParameter string `json:"limitParameter,omitempty"`
+
+	// Limit is the number of groups requested per page when using offset pagination. Default is "100"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Limit",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	Limit int `json:"limit,omitempty"`
+}
+
+// HTTPMapping represents the expressions extracting groups from a response
+// +k8s:openapi-gen=true
+type HTTPMapping struct {
+	// Language is the language of the expressions. JSONPath expressions are evaluated against the response or group while CEL expressions reference the "response" or "group" variables. Default is "JSONPath"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Expression Language",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:JSONPath","urn:alm:descriptor:com.tectonic.ui:select:CEL"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=JSONPath;CEL
+	Language ExpressionLanguage `json:"language,omitempty"`
+
+	// Groups is an expression selecting the list of groups from the response
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Groups",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Groups string `json:"groups"`
+
+	// Name is an expression extracting the name of a group
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// UID is an expression extracting the unique identifier of a group
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="UID",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	UID string `json:"uid,omitempty"`
+
+	// Members is an expression extracting the names of the users within a group
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Members",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Members string `json:"members,omitempty"`
+
+	// Attributes are expressions extracting values added as annotations to a group, keyed by the annotation name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Attributes"
+	// +kubebuilder:validation:Optional
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// StaticProvider represents groups defined within the GroupSync or a ConfigMap or Secret
+// +k8s:openapi-gen=true
+type StaticProvider struct {
+	// Groups are the groups to synchronize
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Groups"
+	// +kubebuilder:validation:Optional
+	Groups []StaticGroup `json:"groups,omitempty"`
+
+	// Source is a reference to a ConfigMap or Secret containing a YAML list of additional groups to synchronize. The key defaults to "groups.yaml"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Containing the Groups"
+	// +kubebuilder:validation:Optional
+	Source *ObjectRef `json:"source,omitempty"`
+
+	// Prune Whether to prune groups that are no longer defined. Default is false
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prune",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	Prune bool `json:"prune,omitempty"`
+}
+
+// FileProvider represents groups defined in a file mounted within the operator
+// +k8s:openapi-gen=true
+type FileProvider struct {
+	// Path is the location of a file containing a YAML list of groups to synchronize, relative to the group file directory of the operator
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Path",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Path string `json:"path"`
+
+	// Prune Whether to prune groups that are no longer defined. Default is false
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prune",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	Prune bool `json:"prune,omitempty"`
+}
+
+// StaticGroup represents a statically defined group
+// +k8s:openapi-gen=true
+type StaticGroup struct {
+	// Name is the name of the group
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Users are the names of the users within the group
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Users",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Users []string `json:"users,omitempty"`
+
+	// Annotations are added to the group
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Annotations"
+	// +kubebuilder:validation:Optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ObjectRef represents a reference to an item within a Secret
+// +k8s:openapi-gen=true
+type ObjectRef struct {
+
+	// Key represents the specific key to reference from the resource
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Key",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Key string `json:"key,omitempty"`
+
+	// Name represents the name of the resource
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Namespace represents the namespace containing the resource
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Namespace",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Namespace string `json:"namespace"`
+
+	// Kind is a string value representing the resource type
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Kind",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:ConfigMap","urn:alm:descriptor:com.tectonic.ui:select:Secret"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum:={"ConfigMap","Secret"}
+	// +kubebuilder:default="Secret"
+	Kind ObjectRefKind `json:"kind,omitempty"`
+}
+
+func (g *GroupSync) GetConditions() []metav1.Condition {
+	return g.Status.Conditions
+}
+
+func (g *GroupSync) SetConditions(conditions []metav1.Condition) {
+	g.Status.Conditions = conditions
+}
+
+func init() {
+	SchemeBuilder.Register(&GroupSync{}, &GroupSyncList{})
+}
@@ -0,0 +1,1021 @@
+//go:build !ignore_autogenerated
+
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	legacyconfigv1 "github.com/openshift/api/legacyconfig/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureProvider) DeepCopyInto(out *AzureProvider) {
+	*out = *in
+	in.ProviderBase.DeepCopyInto(&out.ProviderBase)
+	in.HTTPClientOptions.DeepCopyInto(&out.HTTPClientOptions)
+	in.GroupFilter.DeepCopyInto(&out.GroupFilter)
+	if in.BaseGroups != nil {
+		in, out := &in.BaseGroups, &out.BaseGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UserNameAttributes != nil {
+		in, out := &in.UserNameAttributes, &out.UserNameAttributes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureProvider.
+func (in *AzureProvider) DeepCopy() *AzureProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositeGroup) DeepCopyInto(out *CompositeGroup) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]CompositeGroupRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.Intersect != nil {
+		in, out := &in.Intersect, &out.Intersect
+		*out = make([]CompositeGroupRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]CompositeGroupRef, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositeGroup.
+func (in *CompositeGroup) DeepCopy() *CompositeGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositeGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositeGroupRef) DeepCopyInto(out *CompositeGroupRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositeGroupRef.
+func (in *CompositeGroupRef) DeepCopy() *CompositeGroupRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositeGroupRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalProvider) DeepCopyInto(out *ExternalProvider) {
+	*out = *in
+	in.ProviderBase.DeepCopyInto(&out.ProviderBase)
+	in.GroupFilter.DeepCopyInto(&out.GroupFilter)
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalProvider.
+func (in *ExternalProvider) DeepCopy() *ExternalProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileProvider) DeepCopyInto(out *FileProvider) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileProvider.
+func (in *FileProvider) DeepCopy() *FileProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(FileProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubEnterprise) DeepCopyInto(out *GitHubEnterprise) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubEnterprise.
+func (in *GitHubEnterprise) DeepCopy() *GitHubEnterprise {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubEnterprise)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubProvider) DeepCopyInto(out *GitHubProvider) {
+	*out = *in
+	in.ProviderBase.DeepCopyInto(&out.ProviderBase)
+	in.HTTPClientOptions.DeepCopyInto(&out.HTTPClientOptions)
+	if in.Organizations != nil {
+		in, out := &in.Organizations, &out.Organizations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Enterprise != nil {
+		in, out := &in.Enterprise, &out.Enterprise
+		*out = new(GitHubEnterprise)
+		**out = **in
+	}
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = new(GitHubRepositories)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TeamPatterns != nil {
+		in, out := &in.TeamPatterns, &out.TeamPatterns
+		*out = new(PatternFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TeamPrivacy != nil {
+		in, out := &in.TeamPrivacy, &out.TeamPrivacy
+		*out = make([]GitHubTeamPrivacy, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hierarchy != nil {
+		in, out := &in.Hierarchy, &out.Hierarchy
+		*out = new(Hierarchy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubProvider.
+func (in *GitHubProvider) DeepCopy() *GitHubProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubRepositories) DeepCopyInto(out *GitHubRepositories) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = new(PatternFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]GitHubRepositoryPermission, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubRepositories.
+func (in *GitHubRepositories) DeepCopy() *GitHubRepositories {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubRepositories)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitLabProvider) DeepCopyInto(out *GitLabProvider) {
+	*out = *in
+	in.ProviderBase.DeepCopyInto(&out.ProviderBase)
+	in.HTTPClientOptions.DeepCopyInto(&out.HTTPClientOptions)
+	in.GroupFilter.DeepCopyInto(&out.GroupFilter)
+	if in.Hierarchy != nil {
+		in, out := &in.Hierarchy, &out.Hierarchy
+		*out = new(Hierarchy)
+		**out = **in
+	}
+	if in.AccessLevelGroups != nil {
+		in, out := &in.AccessLevelGroups, &out.AccessLevelGroups
+		*out = make([]GitLabAccessLevel, len(*in))
+		copy(*out, *in)
+	}
+	if in.MemberStates != nil {
+		in, out := &in.MemberStates, &out.MemberStates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLabProvider.
+func (in *GitLabProvider) DeepCopy() *GitLabProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(GitLabProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupFilter) DeepCopyInto(out *GroupFilter) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GroupPatterns != nil {
+		in, out := &in.GroupPatterns, &out.GroupPatterns
+		*out = new(PatternFilter)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupFilter.
+func (in *GroupFilter) DeepCopy() *GroupFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupSync) DeepCopyInto(out *GroupSync) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupSync.
+func (in *GroupSync) DeepCopy() *GroupSync {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupSync)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GroupSync) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupSyncList) DeepCopyInto(out *GroupSyncList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GroupSync, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupSyncList.
+func (in *GroupSyncList) DeepCopy() *GroupSyncList {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupSyncList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GroupSyncList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupSyncSpec) DeepCopyInto(out *GroupSyncSpec) {
+	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]Provider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]Target, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Composites != nil {
+		in, out := &in.Composites, &out.Composites
+		*out = make([]CompositeGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupSyncSpec.
+func (in *GroupSyncSpec) DeepCopy() *GroupSyncSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupSyncSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupSyncStatus) DeepCopyInto(out *GroupSyncStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSyncSuccessTime != nil {
+		in, out := &in.LastSyncSuccessTime, &out.LastSyncSuccessTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]TargetStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LimitViolations != nil {
+		in, out := &in.LimitViolations, &out.LimitViolations
+		*out = make([]LimitViolation, len(*in))
+		copy(*out, *in)
+	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]ProviderStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupSyncStatus.
+func (in *GroupSyncStatus) DeepCopy() *GroupSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPClientOptions) DeepCopyInto(out *HTTPClientOptions) {
+	*out = *in
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(ObjectRef)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(Proxy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPClientOptions.
+func (in *HTTPClientOptions) DeepCopy() *HTTPClientOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPClientOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPMapping) DeepCopyInto(out *HTTPMapping) {
+	*out = *in
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPMapping.
+func (in *HTTPMapping) DeepCopy() *HTTPMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPPagination) DeepCopyInto(out *HTTPPagination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPPagination.
+func (in *HTTPPagination) DeepCopy() *HTTPPagination {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPPagination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProvider) DeepCopyInto(out *HTTPProvider) {
+	*out = *in
+	in.ProviderBase.DeepCopyInto(&out.ProviderBase)
+	in.HTTPClientOptions.DeepCopyInto(&out.HTTPClientOptions)
+	in.GroupFilter.DeepCopyInto(&out.GroupFilter)
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Pagination != nil {
+		in, out := &in.Pagination, &out.Pagination
+		*out = new(HTTPPagination)
+		**out = **in
+	}
+	in.Mapping.DeepCopyInto(&out.Mapping)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProvider.
+func (in *HTTPProvider) DeepCopy() *HTTPProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Hierarchy) DeepCopyInto(out *Hierarchy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Hierarchy.
+func (in *Hierarchy) DeepCopy() *Hierarchy {
+	if in == nil {
+		return nil
+	}
+	out := new(Hierarchy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IbmSecurityVerifyProvider) DeepCopyInto(out *IbmSecurityVerifyProvider) {
+	*out = *in
+	in.ProviderBase.DeepCopyInto(&out.ProviderBase)
+	in.HTTPClientOptions.DeepCopyInto(&out.HTTPClientOptions)
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]IsvGroupSpec, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IbmSecurityVerifyProvider.
+func (in *IbmSecurityVerifyProvider) DeepCopy() *IbmSecurityVerifyProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(IbmSecurityVerifyProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IsvGroupSpec) DeepCopyInto(out *IsvGroupSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IsvGroupSpec.
+func (in *IsvGroupSpec) DeepCopy() *IsvGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IsvGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakProvider) DeepCopyInto(out *KeycloakProvider) {
+	*out = *in
+	in.ProviderBase.DeepCopyInto(&out.ProviderBase)
+	in.HTTPClientOptions.DeepCopyInto(&out.HTTPClientOptions)
+	in.GroupFilter.DeepCopyInto(&out.GroupFilter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakProvider.
+func (in *KeycloakProvider) DeepCopy() *KeycloakProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LdapProvider) DeepCopyInto(out *LdapProvider) {
+	*out = *in
+	in.ProviderBase.DeepCopyInto(&out.ProviderBase)
+	if in.LDAPGroupUIDToOpenShiftGroupNameMapping != nil {
+		in, out := &in.LDAPGroupUIDToOpenShiftGroupNameMapping, &out.LDAPGroupUIDToOpenShiftGroupNameMapping
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RFC2307Config != nil {
+		in, out := &in.RFC2307Config, &out.RFC2307Config
+		*out = new(legacyconfigv1.RFC2307Config)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ActiveDirectoryConfig != nil {
+		in, out := &in.ActiveDirectoryConfig, &out.ActiveDirectoryConfig
+		*out = new(legacyconfigv1.ActiveDirectoryConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AugmentedActiveDirectoryConfig != nil {
+		in, out := &in.AugmentedActiveDirectoryConfig, &out.AugmentedActiveDirectoryConfig
+		*out = new(legacyconfigv1.AugmentedActiveDirectoryConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Whitelist != nil {
+		in, out := &in.Whitelist, &out.Whitelist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Blacklist != nil {
+		in, out := &in.Blacklist, &out.Blacklist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapProvider.
+func (in *LdapProvider) DeepCopy() *LdapProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(LdapProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LimitViolation) DeepCopyInto(out *LimitViolation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LimitViolation.
+func (in *LimitViolation) DeepCopy() *LimitViolation {
+	if in == nil {
+		return nil
+	}
+	out := new(LimitViolation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Limits) DeepCopyInto(out *Limits) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Limits.
+func (in *Limits) DeepCopy() *Limits {
+	if in == nil {
+		return nil
+	}
+	out := new(Limits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectRef) DeepCopyInto(out *ObjectRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectRef.
+func (in *ObjectRef) DeepCopy() *ObjectRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OktaProvider) DeepCopyInto(out *OktaProvider) {
+	*out = *in
+	in.ProviderBase.DeepCopyInto(&out.ProviderBase)
+	in.HTTPClientOptions.DeepCopyInto(&out.HTTPClientOptions)
+	in.GroupFilter.DeepCopyInto(&out.GroupFilter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OktaProvider.
+func (in *OktaProvider) DeepCopy() *OktaProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(OktaProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PatternFilter) DeepCopyInto(out *PatternFilter) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatternFilter.
+func (in *PatternFilter) DeepCopy() *PatternFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(PatternFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Provider) DeepCopyInto(out *Provider) {
+	*out = *in
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(Limits)
+		**out = **in
+	}
+	if in.ProviderType != nil {
+		in, out := &in.ProviderType, &out.ProviderType
+		*out = new(ProviderType)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Provider.
+func (in *Provider) DeepCopy() *Provider {
+	if in == nil {
+		return nil
+	}
+	out := new(Provider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderBase) DeepCopyInto(out *ProviderBase) {
+	*out = *in
+	if in.Ca != nil {
+		in, out := &in.Ca, &out.Ca
+		*out = new(ObjectRef)
+		**out = **in
+	}
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(ObjectRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderBase.
+func (in *ProviderBase) DeepCopy() *ProviderBase {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderBase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderStatus) DeepCopyInto(out *ProviderStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSyncSuccessTime != nil {
+		in, out := &in.LastSyncSuccessTime, &out.LastSyncSuccessTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastProbeTime != nil {
+		in, out := &in.LastProbeTime, &out.LastProbeTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderStatus.
+func (in *ProviderStatus) DeepCopy() *ProviderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderType) DeepCopyInto(out *ProviderType) {
+	*out = *in
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitHub != nil {
+		in, out := &in.GitHub, &out.GitHub
+		*out = new(GitHubProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitLab != nil {
+		in, out := &in.GitLab, &out.GitLab
+		*out = new(GitLabProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ldap != nil {
+		in, out := &in.Ldap, &out.Ldap
+		*out = new(LdapProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Keycloak != nil {
+		in, out := &in.Keycloak, &out.Keycloak
+		*out = new(KeycloakProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Okta != nil {
+		in, out := &in.Okta, &out.Okta
+		*out = new(OktaProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IbmSecurityVerify != nil {
+		in, out := &in.IbmSecurityVerify, &out.IbmSecurityVerify
+		*out = new(IbmSecurityVerifyProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ExternalProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Static != nil {
+		in, out := &in.Static, &out.Static
+		*out = new(StaticProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(FileProvider)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderType.
+func (in *ProviderType) DeepCopy() *ProviderType {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Proxy) DeepCopyInto(out *Proxy) {
+	*out = *in
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(ObjectRef)
+		**out = **in
+	}
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Proxy.
+func (in *Proxy) DeepCopy() *Proxy {
+	if in == nil {
+		return nil
+	}
+	out := new(Proxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticGroup) DeepCopyInto(out *StaticGroup) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticGroup.
+func (in *StaticGroup) DeepCopy() *StaticGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticProvider) DeepCopyInto(out *StaticProvider) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]StaticGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(ObjectRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticProvider.
+func (in *StaticProvider) DeepCopy() *StaticProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Target) DeepCopyInto(out *Target) {
+	*out = *in
+	if in.KubeconfigSecret != nil {
+		in, out := &in.KubeconfigSecret, &out.KubeconfigSecret
+		*out = new(ObjectRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Target.
+func (in *Target) DeepCopy() *Target {
+	if in == nil {
+		return nil
+	}
+	out := new(Target)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetStatus) DeepCopyInto(out *TargetStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSyncSuccessTime != nil {
+		in, out := &in.LastSyncSuccessTime, &out.LastSyncSuccessTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetStatus.
+func (in *TargetStatus) DeepCopy() *TargetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
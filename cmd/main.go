@@ -18,15 +18,20 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	userv1 "github.com/openshift/api/user/v1"
 	"github.com/redhat-cop/operator-utils/pkg/util"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -38,7 +43,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 
 	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
 	"github.com/redhat-cop/group-sync-operator/internal/controller"
+	"github.com/redhat-cop/group-sync-operator/internal/health"
+	"github.com/redhat-cop/group-sync-operator/internal/sharding"
+	"github.com/redhat-cop/group-sync-operator/internal/storageversion"
+	"github.com/redhat-cop/group-sync-operator/pkg/syncer"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -49,18 +59,21 @@ var (
 )
 
 const (
-	defaultLeaseDuration = 45 * time.Second
-	defaultRenewDeadline = 30 * time.Second
-	defaultRetryPeriod   = 10 * time.Second
+	defaultLeaseDuration        = 45 * time.Second
+	defaultRenewDeadline        = 30 * time.Second
+	defaultRetryPeriod          = 10 * time.Second
+	serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
 )
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(redhatcopv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(redhatcopv1beta1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 
 	utilruntime.Must(userv1.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 
 }
 
@@ -72,6 +85,18 @@ func main() {
 	var renewDeadline time.Duration
 	var retryPeriod time.Duration
 	var probeAddr string
+	var shardCount int
+	var shardLeaseNamespace string
+	var shardIdentity string
+	var watchNamespaces string
+	var restrictObjectRefNamespace bool
+	var enableWebhooks bool
+	var webhookCertDir string
+	var migrateStorageVersion bool
+	var externalPluginDirectory string
+	var groupFileDirectory string
+	var providerProbeInterval time.Duration
+	var providerReadinessCheck bool
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8443", "The address the metric endpoint binds to.")
 	flag.BoolVar(&metricsSecure, "metrics-secure", true,
 		"If set, the metrics endpoint is served securely via HTTPS with authentication and authorization.")
@@ -85,6 +110,32 @@ func main() {
 		"Configure leader election lease renew deadline")
 	flag.DurationVar(&retryPeriod, "leaderRetryPeriod", defaultRetryPeriod,
 		"Configure leader election lease retry period")
+	flag.StringVar(&watchNamespaces, "watch-namespaces", "",
+		"Comma separated list of namespaces to watch for GroupSync resources. All namespaces are watched when not set.")
+	flag.BoolVar(&restrictObjectRefNamespace, "restrict-object-ref-namespace", false,
+		"If set, Secrets and ConfigMaps referenced by a GroupSync must be located in the namespace of the GroupSync.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", true,
+		"If set, the GroupSync conversion webhook is served. Disable when running the operator outside of the cluster.")
+	flag.StringVar(&webhookCertDir, "webhook-cert-dir", "",
+		"Directory containing the certificate and key used to serve webhooks. Defaults to the controller-runtime default.")
+	flag.BoolVar(&migrateStorageVersion, "migrate-storage-version", true,
+		"If set, existing GroupSyncs are rewritten using the current storage version on startup.")
+	flag.StringVar(&externalPluginDirectory, "external-plugin-directory", "",
+		"Directory containing the plugins executed by external providers. External providers are disabled when not set.")
+	flag.StringVar(&groupFileDirectory, "group-file-directory", "",
+		"Directory containing the group files read by file providers. File providers are disabled when not set.")
+	flag.DurationVar(&providerProbeInterval, "provider-probe-interval", 0,
+		"Interval at which connectivity to each provider is probed between scheduled synchronizations. Probing is disabled when set to 0.")
+	flag.BoolVar(&providerReadinessCheck, "provider-readiness-check", false,
+		"If set, the operator is reported as not ready while the most recent synchronization or probe of any provider failed. "+
+			"Cannot be combined with webhooks.")
+	flag.IntVar(&shardCount, "shard-count", 0,
+		"Number of shards to distribute GroupSyncs across replicas. "+
+			"Sharding is disabled when set to 0 and cannot be combined with leader election.")
+	flag.StringVar(&shardLeaseNamespace, "shard-lease-namespace", "",
+		"Namespace containing the shard Leases. Defaults to the namespace of the operator.")
+	flag.StringVar(&shardIdentity, "shard-identity", "",
+		"Identity of this replica when claiming shards. Defaults to the hostname.")
 
 	opts := zap.Options{
 		Development: true,
@@ -94,6 +145,22 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if shardCount < 0 {
+		setupLog.Error(fmt.Errorf("invalid shard count: %d", shardCount), "unable to configure sharding")
+		os.Exit(1)
+	}
+
+	if shardCount > 0 && enableLeaderElection {
+		setupLog.Error(fmt.Errorf("sharding cannot be combined with leader election"), "unable to configure sharding")
+		os.Exit(1)
+	}
+
+	// The conversion webhook is only reachable through ready pods, so an unhealthy provider would make GroupSyncs unavailable
+	if providerReadinessCheck && enableWebhooks {
+		setupLog.Error(fmt.Errorf("the provider readiness check cannot be combined with webhooks"), "unable to configure provider readiness check")
+		os.Exit(1)
+	}
+
 	metricsOpts := metricsserver.Options{BindAddress: metricsAddr}
 	if metricsSecure {
 		metricsOpts.SecureServing = true
@@ -101,6 +168,23 @@ func main() {
 		metricsOpts.FilterProvider = filters.WithAuthenticationAndAuthorization
 	}
 
+	syncer.RestrictObjectRefNamespace = restrictObjectRefNamespace
+	syncer.ExternalPluginDirectory = externalPluginDirectory
+	syncer.GroupFileDirectory = groupFileDirectory
+
+	cacheOpts := cache.Options{}
+	namespaces := []string{}
+	if watchNamespaces != "" {
+		cacheOpts.DefaultNamespaces = map[string]cache.Config{}
+		for _, namespace := range strings.Split(watchNamespaces, ",") {
+			if namespace = strings.TrimSpace(namespace); namespace != "" {
+				cacheOpts.DefaultNamespaces[namespace] = cache.Config{}
+				namespaces = append(namespaces, namespace)
+			}
+		}
+		setupLog.Info("watching namespaces", "namespaces", watchNamespaces)
+	}
+
 	options := ctrl.Options{
 		Scheme:                     scheme,
 		Metrics:                    metricsOpts,
@@ -111,6 +195,8 @@ func main() {
 		LeaseDuration:              &leaseDuration,
 		RenewDeadline:              &renewDeadline,
 		RetryPeriod:                &retryPeriod,
+		Cache:                      cacheOpts,
+		WebhookServer:              webhook.NewServer(webhook.Options{CertDir: webhookCertDir}),
 		Client: client.Options{
 			Cache: &client.CacheOptions{
 				DisableFor: []client.Object{&v1.Secret{}},
@@ -124,13 +210,91 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = (&controller.GroupSyncReconciler{
+	groupSyncReconciler := &controller.GroupSyncReconciler{
 		ReconcilerBase: util.NewReconcilerBase(mgr.GetClient(), mgr.GetScheme(), mgr.GetConfig(), mgr.GetEventRecorderFor(controllerName), mgr.GetAPIReader()),
 		Log:            ctrl.Log.WithName("controllers").WithName(controllerName),
-	}).SetupWithManager(mgr); err != nil {
+		Health:         health.NewTracker(),
+	}
+
+	if shardCount > 0 {
+		if shardLeaseNamespace == "" {
+			shardLeaseNamespace, err = getOperatorNamespace()
+			if err != nil {
+				setupLog.Error(err, "unable to determine shard lease namespace")
+				os.Exit(1)
+			}
+		}
+
+		if shardIdentity == "" {
+			shardIdentity, err = os.Hostname()
+			if err != nil {
+				setupLog.Error(err, "unable to determine shard identity")
+				os.Exit(1)
+			}
+		}
+
+		groupSyncReconciler.Shards = &sharding.Coordinator{
+			Client:        mgr.GetClient(),
+			Reader:        mgr.GetAPIReader(),
+			Log:           ctrl.Log.WithName("sharding"),
+			Namespace:     shardLeaseNamespace,
+			Identity:      shardIdentity,
+			ShardCount:    shardCount,
+			LeaseDuration: leaseDuration,
+			RetryPeriod:   retryPeriod,
+			List:          groupSyncReconciler.ListGroupSyncs,
+		}
+
+		if err := mgr.Add(groupSyncReconciler.Shards); err != nil {
+			setupLog.Error(err, "unable to set up shard coordinator")
+			os.Exit(1)
+		}
+	}
+
+	if err = groupSyncReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", controllerName)
 		os.Exit(1)
 	}
+
+	if providerProbeInterval > 0 {
+		prober := &controller.ProviderProber{
+			Reconciler: groupSyncReconciler,
+			Interval:   providerProbeInterval,
+		}
+
+		if err := mgr.Add(prober); err != nil {
+			setupLog.Error(err, "unable to set up provider probe")
+			os.Exit(1)
+		}
+	}
+
+	if enableWebhooks {
+		if err = ctrl.NewWebhookManagedBy(mgr, &redhatcopv1beta1.GroupSync{}).Complete(); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", controllerName)
+			os.Exit(1)
+		}
+	}
+
+	if migrateStorageVersion {
+		migrator := &storageversion.Migrator{
+			Client: mgr.GetClient(),
+			Reader: mgr.GetAPIReader(),
+			Log:    ctrl.Log.WithName("storageversion"),
+			// GroupSyncs are read from the API server rather than the cache, which is restricted to the watched namespaces
+			Namespaces: namespaces,
+		}
+
+		// Leader election is unavailable when sharding, so the migration runs on the shard coordinator instead
+		if groupSyncReconciler.Shards != nil {
+			migrator.Elected = groupSyncReconciler.Shards.IsCoordinator
+			migrator.RetryPeriod = retryPeriod
+		}
+
+		if err := mgr.Add(migrator); err != nil {
+			setupLog.Error(err, "unable to set up storage version migration")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("health", healthz.Ping); err != nil {
@@ -141,6 +305,12 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if providerReadinessCheck {
+		if err := mgr.AddReadyzCheck("providers", groupSyncReconciler.Health.Check); err != nil {
+			setupLog.Error(err, "unable to set up provider ready check")
+			os.Exit(1)
+		}
+	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
@@ -148,3 +318,12 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+func getOperatorNamespace() (string, error) {
+	namespace, err := os.ReadFile(serviceAccountNamespaceFile)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(namespace)), nil
+}
@@ -19,35 +19,56 @@ package controller
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	userv1 "github.com/openshift/api/user/v1"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redhat-cop/group-sync-operator/internal/health"
+	"github.com/redhat-cop/group-sync-operator/internal/sharding"
 	"github.com/redhat-cop/group-sync-operator/pkg/constants"
 	"github.com/redhat-cop/group-sync-operator/pkg/syncer"
 	"github.com/redhat-cop/operator-utils/pkg/util"
 	"github.com/robfig/cron"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	apimachineryvalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/csaupgrade"
 	kubeclock "k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
 )
 
 var clock kubeclock.Clock = &kubeclock.RealClock{}
 
+// FieldManager is the field manager used when applying groups
+const FieldManager = "group-sync-operator"
+
+// legacyFieldManagers are the field managers of groups updated by versions of the operator prior to the use of server-side apply
+var legacyFieldManagers = sets.New("manager")
+
 // GroupSyncReconciler reconciles a GroupSync object
 type GroupSyncReconciler struct {
-	Log logr.Logger
+	Log    logr.Logger
+	Shards *sharding.Coordinator
+	Health *health.Tracker
 	util.ReconcilerBase
+
+	targetClientsLock sync.Mutex
+	targetClients     map[types.NamespacedName]map[string]targetClient
 }
 
 // +kubebuilder:rbac:groups=redhatcop.redhat.io,resources=groupsyncs,verbs=get;list;watch;create;update;patch;delete
@@ -60,19 +81,29 @@ func (r *GroupSyncReconciler) Reconcile(context context.Context, req ctrl.Reques
 	logger := r.Log.WithValues("groupsync", req.NamespacedName)
 
 	// Fetch the GroupSync instance
-	instance := &redhatcopv1alpha1.GroupSync{}
+	instance := &redhatcopv1beta1.GroupSync{}
 	err := r.GetClient().Get(context, req.NamespacedName, instance)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			r.removeProviderHealth(req.NamespacedName)
+			r.setCachedTargetClients(req.NamespacedName, nil)
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
 		return ctrl.Result{}, err
 	}
 
+	// Skip GroupSyncs belonging to a shard owned by another replica
+	if r.Shards != nil && !r.Shards.Owns(instance) {
+		logger.V(1).Info("Skipping GroupSync Owned by Another Shard", "Shard", sharding.ShardFor(instance, r.Shards.ShardCount))
+		r.removeProviderHealth(req.NamespacedName)
+		r.setCachedTargetClients(req.NamespacedName, nil)
+		return ctrl.Result{}, nil
+	}
+
 	// Get Group Sync Manager
 	groupSyncMgr, err := syncer.GetGroupSyncMgr(instance, r.ReconcilerBase)
 
@@ -97,165 +128,322 @@ func (r *GroupSyncReconciler) Reconcile(context context.Context, req ctrl.Reques
 
 	syncErrors := []error{}
 
+	// Connect to Remote Clusters
+	targets := r.getTargetClusters(context, instance)
+
+	instance.Status.LimitViolations = nil
+	r.retainProviderHealth(instance)
+
 	// Execute Each Provider Syncer
+	providerGroups := map[string][]userv1.Group{}
+	var nextMemberExpiry *time.Time
 	for _, groupSyncer := range groupSyncMgr.GroupSyncers {
+		groups, memberExpiry, ok := r.executeSyncer(context, instance, groupSyncer, targets, &syncErrors, logger)
+		if ok {
+			providerGroups[groupSyncer.GetProviderName()] = groups
+		}
+		if memberExpiry != nil && (nextMemberExpiry == nil || memberExpiry.Before(*nextMemberExpiry)) {
+			nextMemberExpiry = memberExpiry
+		}
+	}
+
+	// Execute Each Composite Syncer Using the Groups Synchronized by Providers
+	for _, compositeSyncer := range groupSyncMgr.CompositeSyncers {
+		compositeSyncer.ProviderGroups = providerGroups
+		compositeSyncer.LimitViolations = instance.Status.LimitViolations
+		r.executeSyncer(context, instance, compositeSyncer, targets, &syncErrors, logger)
+	}
+
+	// Record Remote Cluster Status
+	for _, target := range targets {
+		for _, err := range target.errors {
+			syncErrors = append(syncErrors, fmt.Errorf("target '%s': %w", target.name, err))
+		}
+	}
+	r.setTargetStatus(instance, targets)
+
+	// Throw error if error occurred during sync
+	if len(syncErrors) > 0 {
+		return r.ManageError(context, instance, utilerrors.NewAggregate(syncErrors))
+	}
 
-		logger.Info("Beginning Sync", "Provider", groupSyncer.GetProviderName())
+	instance.Status.LastSyncSuccessTime = &metav1.Time{Time: clock.Now()}
 
-		prometheusLabels := prometheus.Labels{METRICS_CR_NAMESPACE_LABEL: instance.GetNamespace(), METRICS_CR_NAME_LABEL: instance.GetName(), METRICS_PROVIDER_LABEL: groupSyncer.GetProviderName()}
+	successResult, err := r.ManageSuccess(context, instance)
 
-		// Provider Label
-		providerLabel := fmt.Sprintf("%s_%s", instance.Name, groupSyncer.GetProviderName())
+	if err == nil && instance.Spec.Schedule != "" {
+		sched, _ := cron.ParseStandard(instance.Spec.Schedule)
 
-		// Initialize Connection
-		if err := groupSyncer.Bind(); err != nil {
-			r.manageSyncError(prometheusLabels, &syncErrors, err)
-			continue
+		currentTime := time.Now().UTC()
+		nextScheduledTime := sched.Next(currentTime)
+		nextScheduledSynchronization.With(prometheus.Labels{METRICS_CR_NAMESPACE_LABEL: instance.GetNamespace(), METRICS_CR_NAME_LABEL: instance.GetName()}).Set(float64(nextScheduledTime.UTC().Unix()))
+		successResult.RequeueAfter = nextScheduledTime.Sub(currentTime)
+	}
+
+	// Synchronize again once the next membership expires so that access is removed on time
+	if err == nil && nextMemberExpiry != nil {
+		expiryRequeueAfter := max(nextMemberExpiry.Sub(clock.Now()), time.Second)
+		if successResult.RequeueAfter == 0 || expiryRequeueAfter < successResult.RequeueAfter {
+			logger.Info("Scheduling Sync for Next Membership Expiry", "Expiry", nextMemberExpiry.UTC().Format(time.RFC3339))
+			successResult.RequeueAfter = expiryRequeueAfter
+		}
+	}
+
+	return successResult, err
+}
+
+func (r *GroupSyncReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(&redhatcopv1beta1.GroupSync{}).
+		WithEventFilter(predicate.Or(util.ResourceGenerationOrFinalizerChangedPredicate{}, sharding.ShardLabelChangedPredicate()))
+
+	if r.Shards != nil {
+		builder = builder.WatchesRawSource(source.Channel(r.Shards.Events(), &handler.EnqueueRequestForObject{}))
+	}
+
+	return builder.Complete(r)
+}
+
+// ListGroupSyncs returns all GroupSyncs so that they can be distributed across shards. GroupSyncs are listed from the
+// cache, which only contains the namespaces watched by the operator
+func (r *GroupSyncReconciler) ListGroupSyncs(context context.Context) ([]client.Object, error) {
+	groupSyncs := &redhatcopv1beta1.GroupSyncList{}
+	if err := r.GetClient().List(context, groupSyncs); err != nil {
+		return nil, err
+	}
+
+	objects := []client.Object{}
+	for i := range groupSyncs.Items {
+		objects = append(objects, &groupSyncs.Items[i])
+	}
+
+	return objects, nil
+}
+
+// executeSyncer synchronizes the groups of a single syncer to the local cluster and all remote targets.
+// The groups returned by the syncer are returned along with the time the next membership expires and whether the syncer completed successfully
+func (r *GroupSyncReconciler) executeSyncer(context context.Context, instance *redhatcopv1beta1.GroupSync, groupSyncer syncer.GroupSyncer, targets []*targetCluster, syncErrors *[]error, logger logr.Logger) ([]userv1.Group, *time.Time, bool) {
+
+	logger.Info("Beginning Sync", "Provider", groupSyncer.GetProviderName())
+
+	prometheusLabels := prometheus.Labels{METRICS_CR_NAMESPACE_LABEL: instance.GetNamespace(), METRICS_CR_NAME_LABEL: instance.GetName(), METRICS_PROVIDER_LABEL: groupSyncer.GetProviderName()}
+
+	// Provider Label
+	providerLabel := fmt.Sprintf("%s_%s", instance.Name, groupSyncer.GetProviderName())
+
+	// Composite syncers do not connect to a provider
+	_, isComposite := groupSyncer.(*syncer.CompositeSyncer)
+
+	// Initialize Connection
+	if err := groupSyncer.Bind(); err != nil {
+		if !isComposite {
+			r.setProviderHealth(instance, groupSyncer.GetProviderName(), ProviderBindFailedReason, err)
 		}
+		r.manageSyncError(prometheusLabels, syncErrors, err)
+		return nil, nil, false
+	}
 
-		// Perform Sync
-		groups, err := groupSyncer.Sync()
+	// Perform Sync
+	groups, err := groupSyncer.Sync()
 
+	if !isComposite {
 		if err != nil {
-			logger.Error(err, "Failed to Complete Sync", "Provider", groupSyncer.GetProviderName())
-			r.manageSyncError(prometheusLabels, &syncErrors, err)
-			continue
+			r.setProviderHealth(instance, groupSyncer.GetProviderName(), ProviderSyncFailedReason, err)
+		} else {
+			providerStatus := r.setProviderHealth(instance, groupSyncer.GetProviderName(), ProviderSyncSucceededReason, nil)
+			providerStatus.LastSyncSuccessTime = &metav1.Time{Time: clock.Now()}
 		}
+	}
 
-		updatedGroups := 0
-		prunedGroups := 0
+	if err != nil {
+		logger.Error(err, "Failed to Complete Sync", "Provider", groupSyncer.GetProviderName())
+		r.manageSyncError(prometheusLabels, syncErrors, err)
+		return nil, nil, false
+	}
 
-		for i, group := range groups {
+	// Remove Expired Members
+	nextMemberExpiry, err := syncer.RemoveExpiredMembers(groups, clock.Now())
+	if err != nil {
+		logger.Error(err, "Failed to Process Membership Expiry", "Provider", groupSyncer.GetProviderName())
+		r.manageSyncError(prometheusLabels, syncErrors, err)
+		return nil, nil, false
+	}
 
-			// Verify valid Group Names
-			if instance.Spec.ExcludeInvalidGroupNames {
-				msgs := apimachineryvalidation.IsDNS1035Label(group.Name)
-				if len(msgs) > 0 {
-					r.Log.Info(fmt.Sprintf("Group '%s' contains invalid name: %s", group.Name, strings.Join(msgs, ",")))
-					continue
-				}
-			}
+	// Enforce Provider Limits
+	groups, skippedGroups, violations, err := syncer.EnforceLimits(groupSyncer.GetProviderName(), syncer.GetProviderLimits(instance, groupSyncer.GetProviderName()), groups)
+	instance.Status.LimitViolations = append(instance.Status.LimitViolations, violations...)
+	groupSyncLimitViolations.With(prometheusLabels).Set(float64(len(violations)))
 
-			ocpGroup := &userv1.Group{}
-			err := r.GetClient().Get(context, types.NamespacedName{Name: group.Name, Namespace: ""}, ocpGroup)
+	if err != nil {
+		logger.Error(err, "Provider Limits Exceeded", "Provider", groupSyncer.GetProviderName())
+		r.manageSyncError(prometheusLabels, syncErrors, err)
+		return nil, nil, false
+	}
 
-			if apierrors.IsNotFound(err) {
+	for _, skippedGroup := range skippedGroups {
+		logger.Info("Skipping Group Exceeding Maximum Members", "Provider", groupSyncer.GetProviderName(), "Group", skippedGroup)
+	}
 
-				ocpGroup = &userv1.Group{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       "Group",
-						APIVersion: userv1.GroupVersion.String(),
-					},
-				}
-				ocpGroup.Name = group.Name
+	// Apply Groups to the Local Cluster
+	syncedGroups, updatedGroups, errs := r.applyGroups(context, r.GetClient(), instance, groupSyncer, groups, providerLabel)
+	for _, err := range errs {
+		r.Log.Error(err, "Failed to Create or Update OpenShift Group", "Provider", groupSyncer.GetProviderName())
+		r.manageSyncError(prometheusLabels, syncErrors, err)
+	}
 
-			} else if err != nil {
-				r.manageSyncError(prometheusLabels, &syncErrors, err)
-				continue
-			} else {
-				// Verify this group is not managed by another provider
-				if groupProviderLabel, exists := ocpGroup.Labels[constants.SyncProvider]; !exists || (groupProviderLabel != providerLabel) {
-					r.Log.Info("Group Provider Label Did Not Match Expected Provider Label", "Provider", groupSyncer.GetProviderName(), "Group Name", ocpGroup.Name, "Expected Label", providerLabel, "Found Label", groupProviderLabel)
-					continue
-				}
-			}
+	prunedGroups := 0
 
-			// Copy Annotations/Labels
-			ocpGroupLabels := map[string]string{}
-			ocpGroupAnnotations := map[string]string{}
+	if groupSyncer.GetPrune() {
+		logger.Info("Start Pruning Groups", "Provider", groupSyncer.GetProviderName())
+		prunedGroups, err = r.pruneGroups(context, r.GetClient(), syncedGroups, skippedGroups, groupSyncer.GetProviderName(), providerLabel, logger)
+		if err != nil {
+			r.Log.Error(err, "Failed to Prune Group", "Provider", groupSyncer.GetProviderName())
+			r.manageSyncError(prometheusLabels, syncErrors, err)
+		}
+		logger.Info("Pruning Completed", "Provider", groupSyncer.GetProviderName())
+	}
 
-			if group.GetAnnotations() != nil {
-				ocpGroupAnnotations = group.GetAnnotations()
-			}
+	// Apply Groups to Remote Clusters
+	for _, target := range targets {
+		r.syncTarget(context, target, instance, groupSyncer, groups, skippedGroups, providerLabel, logger)
+	}
 
-			if group.GetLabels() != nil {
-				ocpGroupLabels = group.GetLabels()
-			}
-			ocpGroup.SetLabels(mergeMap(ocpGroup.GetLabels(), ocpGroupLabels))
-			ocpGroup.SetAnnotations(mergeMap(ocpGroup.GetAnnotations(), ocpGroupAnnotations))
+	logger.Info("Sync Completed Successfully", "Provider", groupSyncer.GetProviderName(), "Groups Created or Updated", updatedGroups, "Groups Pruned", prunedGroups)
 
-			// Add Label for new resource
-			ocpGroup.Labels[constants.SyncProvider] = providerLabel
+	// Add Metrics
+	successfulGroupSyncs.With(prometheusLabels).Inc()
+	groupsSynchronized.With(prometheusLabels).Set(float64(updatedGroups))
+	groupSyncError.With(prometheusLabels).Set(0)
+	if groupSyncer.GetPrune() {
+		groupsPruned.With(prometheusLabels).Set(float64(prunedGroups))
+	}
 
-			// Add Gloabl Annotations/Labels
-			now := time.Now().UTC().Format(time.RFC3339)
-			ocpGroup.Annotations[constants.SyncTimestamp] = now
+	return groups, nextMemberExpiry, true
+}
 
-			ocpGroup.Users = group.Users
+func (r *GroupSyncReconciler) manageSyncError(prometheusLabels prometheus.Labels, syncErrors *[]error, err error) {
+
+	unsuccessfulGroupSyncs.With(prometheusLabels).Inc()
+	groupSyncError.With(prometheusLabels).Set(1)
 
-			err = r.CreateOrUpdateResource(context, nil, "", ocpGroup)
+	*syncErrors = append(*syncErrors, err)
+
+}
 
-			group.UID = ocpGroup.UID
-			groups[i] = group
+// applyGroups creates or updates the synchronized groups in the cluster the client communicates with.
+// The returned groups contain the UIDs assigned by that cluster so that they can be used for pruning.
+func (r *GroupSyncReconciler) applyGroups(context context.Context, c client.Client, instance *redhatcopv1beta1.GroupSync, groupSyncer syncer.GroupSyncer, groups []userv1.Group, providerLabel string) ([]userv1.Group, int, []error) {
+	syncedGroups := make([]userv1.Group, len(groups))
+	copy(syncedGroups, groups)
 
-			if err != nil {
-				r.Log.Error(err, "Failed to Create or Update OpenShift Group", "Provider", groupSyncer.GetProviderName())
-				r.manageSyncError(prometheusLabels, &syncErrors, err)
+	updatedGroups := 0
+	errs := []error{}
+
+	for i, group := range syncedGroups {
+
+		// Verify valid Group Names
+		if instance.Spec.ExcludeInvalidGroupNames {
+			msgs := apimachineryvalidation.IsDNS1035Label(group.Name)
+			if len(msgs) > 0 {
+				r.Log.Info(fmt.Sprintf("Group '%s' contains invalid name: %s", group.Name, strings.Join(msgs, ",")))
 				continue
 			}
-
-			updatedGroups++
 		}
 
-		if groupSyncer.GetPrune() {
-			logger.Info("Start Pruning Groups", "Provider", groupSyncer.GetProviderName())
-			prunedGroups, err = r.pruneGroups(context, instance, groups, groupSyncer.GetProviderName(), providerLabel, logger)
-			if err != nil {
-				r.Log.Error(err, "Failed to Prune Group", "Provider", groupSyncer.GetProviderName())
-				r.manageSyncError(prometheusLabels, &syncErrors, err)
+		ocpGroup := &userv1.Group{}
+		err := c.Get(context, types.NamespacedName{Name: group.Name, Namespace: ""}, ocpGroup)
+
+		if err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, err)
+			continue
+		} else if err == nil {
+			// Verify this group is not managed by another provider
+			if groupProviderLabel, exists := ocpGroup.Labels[constants.SyncProvider]; !exists || (groupProviderLabel != providerLabel) {
+				r.Log.Info("Group Provider Label Did Not Match Expected Provider Label", "Provider", groupSyncer.GetProviderName(), "Group Name", ocpGroup.Name, "Expected Label", providerLabel, "Found Label", groupProviderLabel)
+				continue
+			}
+
+			// Retain the existing group when it cannot be applied so that it is not pruned
+			syncedGroups[i].UID = ocpGroup.GetUID()
+
+			// Transfer fields written by previous versions of the operator to the field manager
+			if err := upgradeManagedFields(context, c, ocpGroup); err != nil {
+				errs = append(errs, err)
+				continue
 			}
-			logger.Info("Pruning Completed", "Provider", groupSyncer.GetProviderName())
 		}
 
-		logger.Info("Sync Completed Successfully", "Provider", groupSyncer.GetProviderName(), "Groups Created or Updated", updatedGroups, "Groups Pruned", prunedGroups)
+		// Copy Annotations/Labels
+		ocpGroupLabels := map[string]string{}
+		ocpGroupAnnotations := map[string]string{}
 
-		// Add Metrics
-		successfulGroupSyncs.With(prometheusLabels).Inc()
-		groupsSynchronized.With(prometheusLabels).Set(float64(updatedGroups))
-		groupSyncError.With(prometheusLabels).Set(0)
-		if groupSyncer.GetPrune() {
-			groupsPruned.With(prometheusLabels).Set(float64(prunedGroups))
+		for k, v := range group.GetAnnotations() {
+			ocpGroupAnnotations[k] = v
 		}
-	}
 
-	// Throw error if error occurred during sync
-	if len(syncErrors) > 0 {
-		return r.ManageError(context, instance, utilerrors.NewAggregate(syncErrors))
-	}
+		for k, v := range group.GetLabels() {
+			ocpGroupLabels[k] = v
+		}
 
-	instance.Status.LastSyncSuccessTime = &metav1.Time{Time: clock.Now()}
+		// Add Label for new resource
+		ocpGroupLabels[constants.SyncProvider] = providerLabel
 
-	successResult, err := r.ManageSuccess(context, instance)
+		// Add Gloabl Annotations/Labels
+		now := time.Now().UTC().Format(time.RFC3339)
+		ocpGroupAnnotations[constants.SyncTimestamp] = now
 
-	if err == nil && instance.Spec.Schedule != "" {
-		sched, _ := cron.ParseStandard(instance.Spec.Schedule)
+		users := group.Users
+		if users == nil {
+			users = []string{}
+		}
 
-		currentTime := time.Now().UTC()
-		nextScheduledTime := sched.Next(currentTime)
-		nextScheduledSynchronization.With(prometheus.Labels{METRICS_CR_NAMESPACE_LABEL: instance.GetNamespace(), METRICS_CR_NAME_LABEL: instance.GetName()}).Set(float64(nextScheduledTime.UTC().Unix()))
-		successResult.RequeueAfter = nextScheduledTime.Sub(currentTime)
+		// Apply only the fields owned by the operator so that metadata added by others is retained
+		appliedGroup, err := r.applyGroup(context, c, group.Name, ocpGroupLabels, ocpGroupAnnotations, users)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		syncedGroups[i].UID = appliedGroup.GetUID()
+		updatedGroups++
 	}
 
-	return successResult, err
+	return syncedGroups, updatedGroups, errs
 }
 
-func (r *GroupSyncReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&redhatcopv1alpha1.GroupSync{}).
-		WithEventFilter(util.ResourceGenerationOrFinalizerChangedPredicate{}).
-		Complete(r)
-}
+// applyGroup creates or updates a group using server-side apply. Only the provided labels, annotations and users are
+// owned by the operator. Conflicts with changes made by other field managers are returned as errors
+func (r *GroupSyncReconciler) applyGroup(context context.Context, c client.Client, name string, labels, annotations map[string]string, users []string) (*unstructured.Unstructured, error) {
 
-func (r *GroupSyncReconciler) manageSyncError(prometheusLabels prometheus.Labels, syncErrors *[]error, err error) {
+	appliedGroup := &unstructured.Unstructured{}
+	appliedGroup.SetGroupVersionKind(userv1.GroupVersion.WithKind("Group"))
+	appliedGroup.SetName(name)
+	appliedGroup.SetLabels(labels)
+	appliedGroup.SetAnnotations(annotations)
 
-	unsuccessfulGroupSyncs.With(prometheusLabels).Inc()
-	groupSyncError.With(prometheusLabels).Set(1)
+	if err := unstructured.SetNestedStringSlice(appliedGroup.Object, users, "users"); err != nil {
+		return appliedGroup, err
+	}
 
-	*syncErrors = append(*syncErrors, err)
+	err := c.Apply(context, client.ApplyConfigurationFromUnstructured(appliedGroup), client.FieldOwner(FieldManager))
+
+	return appliedGroup, err
+}
+
+// upgradeManagedFields transfers ownership of the fields of a group written using updates by previous versions of the operator
+// to the field manager so that they are not reported as conflicts once the group is applied
+func upgradeManagedFields(context context.Context, c client.Client, ocpGroup *userv1.Group) error {
+
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(ocpGroup, legacyFieldManagers, FieldManager)
+	if err != nil || patch == nil {
+		return err
+	}
 
+	return c.Patch(context, ocpGroup, client.RawPatch(types.JSONPatchType, patch))
 }
 
-func (r *GroupSyncReconciler) pruneGroups(context context.Context, instance *redhatcopv1alpha1.GroupSync, syncedGroups []userv1.Group, providerName, providerLabel string, logger logr.Logger) (int, error) {
+// pruneGroups removes the groups of a provider that were not synchronized. Retained groups are never removed
+func (r *GroupSyncReconciler) pruneGroups(context context.Context, c client.Client, syncedGroups []userv1.Group, retainedGroups []string, providerName, providerLabel string, logger logr.Logger) (int, error) {
 	prunedGroups := 0
 
 	ocpGroups := &userv1.GroupList{}
@@ -263,7 +451,7 @@ func (r *GroupSyncReconciler) pruneGroups(context context.Context, instance *red
 		client.InNamespace(""),
 		client.MatchingLabels{constants.SyncProvider: providerLabel},
 	}
-	err := r.GetClient().List(context, ocpGroups, opts...)
+	err := c.List(context, ocpGroups, opts...)
 	if err != nil {
 		return prunedGroups, err
 	}
@@ -273,9 +461,9 @@ func (r *GroupSyncReconciler) pruneGroups(context context.Context, instance *red
 		// Remove group if not found in the list of synchronized groups
 		groupFound := isGroupFound(group, syncedGroups)
 
-		if !groupFound {
+		if !groupFound && !slices.Contains(retainedGroups, group.Name) {
 			logger.Info("Pruning Group", "Provider", providerName, "Group", group.Name)
-			err = r.GetClient().Delete(context, &group)
+			err = c.Delete(context, &group)
 			prunedGroups++
 			if err != nil {
 				return prunedGroups, err
@@ -295,18 +483,3 @@ func isGroupFound(canidateGroup userv1.Group, baseGroups []userv1.Group) bool {
 
 	return false
 }
-
-func mergeMap(m1, m2 map[string]string) map[string]string {
-
-	if m1 != nil {
-		for mKey, mValue := range m2 {
-			m1[mKey] = mValue
-		}
-
-		return m1
-
-	} else {
-		return m2
-	}
-
-}
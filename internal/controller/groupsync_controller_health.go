@@ -0,0 +1,195 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redhat-cop/group-sync-operator/pkg/syncer"
+	"github.com/redhat-cop/operator-utils/pkg/util/apis"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+)
+
+const (
+	// ProviderHealthy is the condition type reporting whether a provider could be reached
+	ProviderHealthy = "Healthy"
+
+	ProviderBindFailedReason    = "BindFailed"
+	ProviderSyncFailedReason    = "SyncFailed"
+	ProviderSyncSucceededReason = "SyncSucceeded"
+	ProviderProbeFailedReason   = "ProbeFailed"
+)
+
+// setProviderHealth records whether a provider could be reached in the status of the GroupSync, the provider health
+// metric and the health tracker. The status of the provider is returned so that additional fields can be set
+func (r *GroupSyncReconciler) setProviderHealth(instance *redhatcopv1beta1.GroupSync, providerName string, reason string, err error) *redhatcopv1beta1.ProviderStatus {
+	prometheusLabels := prometheus.Labels{METRICS_CR_NAMESPACE_LABEL: instance.GetNamespace(), METRICS_CR_NAME_LABEL: instance.GetName(), METRICS_PROVIDER_LABEL: providerName}
+
+	condition := metav1.Condition{
+		Type:               ProviderHealthy,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: instance.GetGeneration(),
+		Reason:             reason,
+		Status:             metav1.ConditionTrue,
+	}
+
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Message = err.Error()
+		groupSyncProviderHealthy.With(prometheusLabels).Set(0)
+	} else {
+		groupSyncProviderHealthy.With(prometheusLabels).Set(1)
+	}
+
+	if r.Health != nil {
+		r.Health.Record(types.NamespacedName{Namespace: instance.GetNamespace(), Name: instance.GetName()}, providerName, err)
+	}
+
+	providerStatus := getProviderStatus(instance, providerName)
+	providerStatus.Conditions = apis.AddOrReplaceCondition(condition, providerStatus.Conditions)
+
+	return providerStatus
+}
+
+// getProviderStatus returns the status of a provider, adding it to the status of the GroupSync when not present
+func getProviderStatus(instance *redhatcopv1beta1.GroupSync, providerName string) *redhatcopv1beta1.ProviderStatus {
+	for i := range instance.Status.Providers {
+		if instance.Status.Providers[i].Name == providerName {
+			return &instance.Status.Providers[i]
+		}
+	}
+
+	instance.Status.Providers = append(instance.Status.Providers, redhatcopv1beta1.ProviderStatus{Name: providerName})
+
+	return &instance.Status.Providers[len(instance.Status.Providers)-1]
+}
+
+// retainProviderHealth removes the health of providers that are no longer present in the GroupSync
+func (r *GroupSyncReconciler) retainProviderHealth(instance *redhatcopv1beta1.GroupSync) {
+	providerNames := []string{}
+	for _, provider := range instance.Spec.Providers {
+		providerNames = append(providerNames, provider.Name)
+	}
+
+	var providerStatuses []redhatcopv1beta1.ProviderStatus
+	for _, providerStatus := range instance.Status.Providers {
+		if slices.Contains(providerNames, providerStatus.Name) {
+			providerStatuses = append(providerStatuses, providerStatus)
+			continue
+		}
+
+		groupSyncProviderHealthy.Delete(prometheus.Labels{METRICS_CR_NAMESPACE_LABEL: instance.GetNamespace(), METRICS_CR_NAME_LABEL: instance.GetName(), METRICS_PROVIDER_LABEL: providerStatus.Name})
+	}
+	instance.Status.Providers = providerStatuses
+
+	if r.Health != nil {
+		r.Health.Retain(types.NamespacedName{Namespace: instance.GetNamespace(), Name: instance.GetName()}, providerNames)
+	}
+}
+
+// ProviderProber periodically verifies that the providers of each GroupSync can be reached between scheduled synchronizations
+type ProviderProber struct {
+	Reconciler *GroupSyncReconciler
+	Interval   time.Duration
+}
+
+// NeedLeaderElection ensures that only the replica performing synchronizations probes providers
+func (p *ProviderProber) NeedLeaderElection() bool {
+	return true
+}
+
+// Start probes the providers of each GroupSync at every interval until the context is cancelled
+func (p *ProviderProber) Start(context context.Context) error {
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-context.Done():
+			return nil
+		case <-ticker.C:
+			p.probe(context)
+		}
+	}
+}
+
+// probe binds to the providers of each GroupSync and records failures in the status of the GroupSync
+func (p *ProviderProber) probe(context context.Context) {
+	r := p.Reconciler
+
+	// The cache only contains the namespaces watched by the operator
+	groupSyncs := &redhatcopv1beta1.GroupSyncList{}
+	if err := r.GetClient().List(context, groupSyncs); err != nil {
+		r.Log.Error(err, "Failed to List GroupSyncs to Probe")
+		return
+	}
+
+	for i := range groupSyncs.Items {
+		instance := &groupSyncs.Items[i]
+		logger := r.Log.WithValues("groupsync", types.NamespacedName{Namespace: instance.GetNamespace(), Name: instance.GetName()})
+
+		if r.Shards != nil && !r.Shards.Owns(instance) {
+			continue
+		}
+
+		original := instance.DeepCopy()
+
+		// Configuration errors are reported by the reconciler
+		groupSyncMgr, err := syncer.GetGroupSyncMgr(instance, r.ReconcilerBase)
+		if err != nil {
+			continue
+		}
+		groupSyncMgr.SetDefaults()
+		if err := groupSyncMgr.Validate(); err != nil {
+			continue
+		}
+
+		// Binding to several providers does not connect to the provider, so a successful probe does not show that the
+		// provider is healthy. Only failures are recorded, which are cleared by the next successful synchronization
+		for _, groupSyncer := range groupSyncMgr.GroupSyncers {
+			providerStatus := getProviderStatus(instance, groupSyncer.GetProviderName())
+
+			if err := groupSyncer.Bind(); err != nil {
+				logger.Error(err, "Failed to Probe Provider", "Provider", groupSyncer.GetProviderName())
+				providerStatus = r.setProviderHealth(instance, groupSyncer.GetProviderName(), ProviderProbeFailedReason, err)
+			}
+
+			providerStatus.LastProbeTime = &metav1.Time{Time: clock.Now()}
+		}
+
+		if err := r.GetClient().Status().Patch(context, instance, client.MergeFrom(original)); err != nil {
+			logger.Error(err, "Failed to Record Provider Probe Results")
+		}
+	}
+}
+
+// removeProviderHealth removes the health of every provider of a GroupSync that no longer exists or is synchronized
+// by another replica
+func (r *GroupSyncReconciler) removeProviderHealth(namespacedName types.NamespacedName) {
+	groupSyncProviderHealthy.DeletePartialMatch(prometheus.Labels{METRICS_CR_NAMESPACE_LABEL: namespacedName.Namespace, METRICS_CR_NAME_LABEL: namespacedName.Name})
+
+	if r.Health != nil {
+		r.Health.Retain(namespacedName, nil)
+	}
+}
@@ -0,0 +1,143 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redhat-cop/group-sync-operator/internal/health"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+)
+
+func newTestProviderStatus(name string, status metav1.ConditionStatus, reason string) redhatcopv1beta1.ProviderStatus {
+	return redhatcopv1beta1.ProviderStatus{
+		Name:       name,
+		Conditions: []metav1.Condition{{Type: ProviderHealthy, Status: status, Reason: reason, LastTransitionTime: metav1.Now()}},
+	}
+}
+
+func TestProviderProberRetainsFailedSync(t *testing.T) {
+	staticProvider := func(name string) redhatcopv1beta1.Provider {
+		return redhatcopv1beta1.Provider{
+			Name:         name,
+			ProviderType: &redhatcopv1beta1.ProviderType{Static: &redhatcopv1beta1.StaticProvider{Groups: []redhatcopv1beta1.StaticGroup{{Name: "developers"}}}},
+		}
+	}
+
+	groupSync := &redhatcopv1beta1.GroupSync{
+		ObjectMeta: metav1.ObjectMeta{Name: "groupsync", Namespace: testNamespace},
+		Spec: redhatcopv1beta1.GroupSyncSpec{
+			Providers: []redhatcopv1beta1.Provider{staticProvider("sync-failed"), staticProvider("probe-failed"), staticProvider("healthy")},
+		},
+		Status: redhatcopv1beta1.GroupSyncStatus{
+			Providers: []redhatcopv1beta1.ProviderStatus{
+				newTestProviderStatus("sync-failed", metav1.ConditionFalse, ProviderSyncFailedReason),
+				newTestProviderStatus("probe-failed", metav1.ConditionFalse, ProviderProbeFailedReason),
+				newTestProviderStatus("healthy", metav1.ConditionTrue, ProviderSyncSucceededReason),
+			},
+		},
+	}
+
+	scheme := newTestScheme(t)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(groupSync).WithStatusSubresource(groupSync).Build()
+
+	r := &GroupSyncReconciler{
+		Log:            logr.Discard(),
+		Health:         health.NewTracker(),
+		ReconcilerBase: util.NewReconcilerBase(fakeClient, scheme, nil, nil, fakeClient),
+	}
+
+	namespacedName := types.NamespacedName{Namespace: testNamespace, Name: "groupsync"}
+	r.Health.Record(namespacedName, "sync-failed", errors.New("unable to retrieve groups"))
+	r.Health.Record(namespacedName, "probe-failed", errors.New("connection refused"))
+
+	(&ProviderProber{Reconciler: r}).probe(context.TODO())
+
+	probedGroupSync := &redhatcopv1beta1.GroupSync{}
+	if err := fakeClient.Get(context.TODO(), namespacedName, probedGroupSync); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	// Successful probes do not change the health of providers
+	expected := map[string]string{
+		"sync-failed":  ProviderSyncFailedReason,
+		"probe-failed": ProviderProbeFailedReason,
+		"healthy":      ProviderSyncSucceededReason,
+	}
+
+	for _, providerStatus := range probedGroupSync.Status.Providers {
+		condition := meta.FindStatusCondition(providerStatus.Conditions, ProviderHealthy)
+		if condition == nil || condition.Reason != expected[providerStatus.Name] {
+			t.Errorf("probe() provider '%s' condition = %+v, expected reason %s", providerStatus.Name, condition, expected[providerStatus.Name])
+		}
+		if providerStatus.LastProbeTime == nil {
+			t.Errorf("probe() provider '%s' did not record the probe time", providerStatus.Name)
+		}
+	}
+
+	// Readiness continues to report the failed synchronization and probe
+	err := r.Health.Check(nil)
+	if err == nil || err.Error() != "unhealthy providers: group-sync-operator/groupsync/probe-failed, group-sync-operator/groupsync/sync-failed" {
+		t.Errorf("Check() error = %v, expected the failed synchronization and probe to be reported", err)
+	}
+}
+
+func TestRemoveProviderHealth(t *testing.T) {
+	r := &GroupSyncReconciler{Log: logr.Discard(), Health: health.NewTracker()}
+
+	groupSync := &redhatcopv1beta1.GroupSync{
+		ObjectMeta: metav1.ObjectMeta{Name: "removed-groupsync", Namespace: testNamespace},
+		Spec:       redhatcopv1beta1.GroupSyncSpec{Providers: []redhatcopv1beta1.Provider{{Name: "azure"}, {Name: "okta"}}},
+	}
+	r.setProviderHealth(groupSync, "azure", ProviderSyncSucceededReason, nil)
+	r.setProviderHealth(groupSync, "okta", ProviderSyncFailedReason, errors.New("unauthorized"))
+
+	providerLabels := func(providerName string) prometheus.Labels {
+		return prometheus.Labels{METRICS_CR_NAMESPACE_LABEL: testNamespace, METRICS_CR_NAME_LABEL: "removed-groupsync", METRICS_PROVIDER_LABEL: providerName}
+	}
+
+	// The health of a provider removed from the GroupSync is removed
+	groupSync.Spec.Providers = groupSync.Spec.Providers[:1]
+	r.retainProviderHealth(groupSync)
+
+	if groupSyncProviderHealthy.Delete(providerLabels("okta")) {
+		t.Errorf("retainProviderHealth() retained the health metric of removed provider 'okta'")
+	}
+	if len(groupSync.Status.Providers) != 1 || groupSync.Status.Providers[0].Name != "azure" {
+		t.Errorf("retainProviderHealth() providers = %+v, expected only 'azure'", groupSync.Status.Providers)
+	}
+
+	// The health of every provider of a removed GroupSync is removed
+	r.removeProviderHealth(types.NamespacedName{Namespace: testNamespace, Name: "removed-groupsync"})
+
+	if groupSyncProviderHealthy.Delete(providerLabels("azure")) {
+		t.Errorf("removeProviderHealth() retained the health metric of provider 'azure'")
+	}
+	if err := r.Health.Check(nil); err != nil {
+		t.Errorf("Check() error = %v, expected no unhealthy providers", err)
+	}
+}
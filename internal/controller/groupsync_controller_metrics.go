@@ -9,6 +9,7 @@ const (
 	METRICS_PROVIDER_LABEL     = "provider"
 	METRICS_CR_NAMESPACE_LABEL = "namespace"
 	METRICS_CR_NAME_LABEL      = "name"
+	METRICS_TARGET_LABEL       = "target"
 )
 
 var (
@@ -53,8 +54,29 @@ var (
 			Help: "Error Occurred During Group Synchronization",
 		},
 		[]string{METRICS_PROVIDER_LABEL, METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
+
+	groupSyncLimitViolations = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "group_sync_limit_violations",
+			Help: "Number of Groups or Providers Exceeding Their Limits During Group Synchronization",
+		},
+		[]string{METRICS_PROVIDER_LABEL, METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
+
+	groupSyncProviderHealthy = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "group_sync_provider_healthy",
+			Help: "Whether the Most Recent Synchronization or Probe of a Provider Succeeded",
+		},
+		[]string{METRICS_PROVIDER_LABEL, METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
+
+	groupSyncTargetError = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "group_sync_target_error",
+			Help: "Error Occurred During Group Synchronization to a Target Cluster",
+		},
+		[]string{METRICS_TARGET_LABEL, METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
 )
 
 func init() {
-	metrics.Registry.MustRegister(successfulGroupSyncs, unsuccessfulGroupSyncs, groupsSynchronized, nextScheduledSynchronization, groupSyncError)
+	metrics.Registry.MustRegister(successfulGroupSyncs, unsuccessfulGroupSyncs, groupsSynchronized, nextScheduledSynchronization, groupSyncError, groupSyncLimitViolations, groupSyncProviderHealthy, groupSyncTargetError)
 }
@@ -0,0 +1,205 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redhat-cop/group-sync-operator/pkg/syncer"
+	"github.com/redhat-cop/operator-utils/pkg/util/apis"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+)
+
+// targetCluster represents a remote cluster that synchronized groups are applied to
+type targetCluster struct {
+	name          string
+	client        client.Client
+	updatedGroups int
+	prunedGroups  int
+	errors        []error
+}
+
+// targetClient is a client for a remote cluster built from the kubeconfig in a given resource at a given resourceVersion
+type targetClient struct {
+	resourceRef     string
+	resourceVersion string
+	client          client.Client
+}
+
+// getTargetClusters builds a client for each remote cluster referenced by the GroupSync.
+// Targets that cannot be reached are returned without a client and with the error recorded.
+// Only the clients of the targets that could be reached are retained, so that clients of removed targets and of
+// kubeconfigs that can no longer be read are released.
+func (r *GroupSyncReconciler) getTargetClusters(context context.Context, instance *redhatcopv1beta1.GroupSync) []*targetCluster {
+	namespacedName := types.NamespacedName{Namespace: instance.GetNamespace(), Name: instance.GetName()}
+	cachedClients := r.getCachedTargetClients(namespacedName)
+	targetClients := map[string]targetClient{}
+	targets := []*targetCluster{}
+
+	for i := range instance.Spec.Targets {
+		target := &instance.Spec.Targets[i]
+		targetCluster := &targetCluster{name: target.Name}
+		targets = append(targets, targetCluster)
+
+		targetClient, err := r.getTargetClient(context, instance, target, cachedClients[target.Name])
+		if err != nil {
+			targetCluster.errors = append(targetCluster.errors, err)
+			continue
+		}
+
+		targetClients[target.Name] = targetClient
+		targetCluster.client = targetClient.client
+	}
+
+	r.setCachedTargetClients(namespacedName, targetClients)
+
+	return targets
+}
+
+// getTargetClient returns the client for a remote cluster. The cached client is reused until the resource containing
+// the kubeconfig is changed, either by referencing another resource or by updating its content
+func (r *GroupSyncReconciler) getTargetClient(context context.Context, instance *redhatcopv1beta1.GroupSync, target *redhatcopv1beta1.Target, cachedClient targetClient) (targetClient, error) {
+	kubeconfig, resourceVersion, err := syncer.GetTargetKubeconfig(context, r.GetClient(), instance, target)
+	if err != nil {
+		return targetClient{}, err
+	}
+
+	resourceRef := fmt.Sprintf("%s/%s/%s/%s", target.KubeconfigSecret.Kind, target.KubeconfigSecret.Namespace, target.KubeconfigSecret.Name, target.KubeconfigSecret.Key)
+
+	if cachedClient.client != nil && resourceVersion != "" && cachedClient.resourceRef == resourceRef && cachedClient.resourceVersion == resourceVersion {
+		return cachedClient, nil
+	}
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return targetClient{}, err
+	}
+
+	remoteClient, err := client.New(restConfig, client.Options{Scheme: r.GetScheme()})
+	if err != nil {
+		return targetClient{}, err
+	}
+
+	return targetClient{resourceRef: resourceRef, resourceVersion: resourceVersion, client: remoteClient}, nil
+}
+
+// getCachedTargetClients returns the clients of the targets of a GroupSync by target name
+func (r *GroupSyncReconciler) getCachedTargetClients(namespacedName types.NamespacedName) map[string]targetClient {
+	r.targetClientsLock.Lock()
+	defer r.targetClientsLock.Unlock()
+
+	return r.targetClients[namespacedName]
+}
+
+// setCachedTargetClients replaces the clients of the targets of a GroupSync. The clients of a GroupSync are removed
+// entirely when none are given
+func (r *GroupSyncReconciler) setCachedTargetClients(namespacedName types.NamespacedName, targetClients map[string]targetClient) {
+	r.targetClientsLock.Lock()
+	defer r.targetClientsLock.Unlock()
+
+	if len(targetClients) == 0 {
+		delete(r.targetClients, namespacedName)
+		return
+	}
+
+	if r.targetClients == nil {
+		r.targetClients = map[types.NamespacedName]map[string]targetClient{}
+	}
+	r.targetClients[namespacedName] = targetClients
+}
+
+// syncTarget applies the groups synchronized from a provider to a remote cluster
+func (r *GroupSyncReconciler) syncTarget(context context.Context, target *targetCluster, instance *redhatcopv1beta1.GroupSync, groupSyncer syncer.GroupSyncer, groups []userv1.Group, retainedGroups []string, providerLabel string, logger logr.Logger) {
+	if target.client == nil {
+		return
+	}
+
+	syncedGroups, updatedGroups, errs := r.applyGroups(context, target.client, instance, groupSyncer, groups, providerLabel)
+	for _, err := range errs {
+		r.Log.Error(err, "Failed to Create or Update OpenShift Group", "Provider", groupSyncer.GetProviderName(), "Target", target.name)
+	}
+	target.updatedGroups += updatedGroups
+	target.errors = append(target.errors, errs...)
+
+	if groupSyncer.GetPrune() {
+		prunedGroups, err := r.pruneGroups(context, target.client, syncedGroups, retainedGroups, groupSyncer.GetProviderName(), providerLabel, logger.WithValues("Target", target.name))
+		if err != nil {
+			r.Log.Error(err, "Failed to Prune Group", "Provider", groupSyncer.GetProviderName(), "Target", target.name)
+			target.errors = append(target.errors, err)
+		}
+		target.prunedGroups += prunedGroups
+	}
+
+	logger.Info("Target Sync Completed", "Provider", groupSyncer.GetProviderName(), "Target", target.name, "Groups Created or Updated", updatedGroups)
+}
+
+// setTargetStatus records the outcome of the synchronization against each remote cluster
+func (r *GroupSyncReconciler) setTargetStatus(instance *redhatcopv1beta1.GroupSync, targets []*targetCluster) {
+	previousStatus := map[string]redhatcopv1beta1.TargetStatus{}
+	for _, targetStatus := range instance.Status.Targets {
+		previousStatus[targetStatus.Name] = targetStatus
+	}
+
+	var targetStatuses []redhatcopv1beta1.TargetStatus
+
+	for _, target := range targets {
+		targetStatus := previousStatus[target.name]
+		targetStatus.Name = target.name
+		targetStatus.GroupsSynchronized = target.updatedGroups
+		targetStatus.GroupsPruned = target.prunedGroups
+
+		prometheusLabels := prometheus.Labels{METRICS_CR_NAMESPACE_LABEL: instance.GetNamespace(), METRICS_CR_NAME_LABEL: instance.GetName(), METRICS_TARGET_LABEL: target.name}
+
+		var condition metav1.Condition
+		if len(target.errors) > 0 {
+			condition = metav1.Condition{
+				Type:               apis.ReconcileError,
+				LastTransitionTime: metav1.Now(),
+				ObservedGeneration: instance.GetGeneration(),
+				Message:            utilerrors.NewAggregate(target.errors).Error(),
+				Reason:             apis.ReconcileErrorReason,
+				Status:             metav1.ConditionTrue,
+			}
+			groupSyncTargetError.With(prometheusLabels).Set(1)
+		} else {
+			condition = metav1.Condition{
+				Type:               apis.ReconcileSuccess,
+				LastTransitionTime: metav1.Now(),
+				ObservedGeneration: instance.GetGeneration(),
+				Reason:             apis.ReconcileSuccessReason,
+				Status:             metav1.ConditionTrue,
+			}
+			targetStatus.LastSyncSuccessTime = &metav1.Time{Time: clock.Now()}
+			groupSyncTargetError.With(prometheusLabels).Set(0)
+		}
+		targetStatus.Conditions = apis.AddOrReplaceCondition(condition, targetStatus.Conditions)
+
+		targetStatuses = append(targetStatuses, targetStatus)
+	}
+
+	instance.Status.Targets = targetStatuses
+}
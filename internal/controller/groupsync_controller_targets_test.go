@@ -0,0 +1,330 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	"github.com/redhat-cop/operator-utils/pkg/util/apis"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+)
+
+const (
+	testNamespace     = "group-sync-operator"
+	testProviderName  = "provider"
+	testProviderLabel = "groupsync_provider"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: remote
+  cluster:
+    server: https://remote.example.com:6443
+contexts:
+- name: remote
+  context:
+    cluster: remote
+    user: remote
+current-context: remote
+users:
+- name: remote
+  user:
+    token: token
+`
+
+// testGroupSyncer is a GroupSyncer returning a fixed provider name and prune setting
+type testGroupSyncer struct {
+	prune bool
+}
+
+func (t *testGroupSyncer) GetProviderName() string       { return testProviderName }
+func (t *testGroupSyncer) Init() bool                    { return true }
+func (t *testGroupSyncer) Bind() error                   { return nil }
+func (t *testGroupSyncer) Sync() ([]userv1.Group, error) { return nil, nil }
+func (t *testGroupSyncer) Validate() error               { return nil }
+func (t *testGroupSyncer) GetPrune() bool                { return t.prune }
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{clientgoscheme.AddToScheme, userv1.AddToScheme, redhatcopv1beta1.AddToScheme} {
+		if err := addToScheme(scheme); err != nil {
+			t.Fatalf("AddToScheme() error = %v", err)
+		}
+	}
+
+	return scheme
+}
+
+func newTestReconciler(t *testing.T, objects ...client.Object) *GroupSyncReconciler {
+	t.Helper()
+
+	scheme := newTestScheme(t)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	return &GroupSyncReconciler{
+		Log:            logr.Discard(),
+		ReconcilerBase: util.NewReconcilerBase(fakeClient, scheme, nil, nil, fakeClient),
+	}
+}
+
+// newTestGroup returns a group whose fields are owned by the field manager of previous versions of the operator
+func newTestGroup(name string, uid string, providerLabel string, users ...string) *userv1.Group {
+	return newTestGroupManagedBy("manager", name, uid, providerLabel, users...)
+}
+
+// newTestGroupManagedBy returns a group whose fields are owned by the field manager using updates
+func newTestGroupManagedBy(manager string, name string, uid string, providerLabel string, users ...string) *userv1.Group {
+	return &userv1.Group{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			UID:    types.UID(uid),
+			Labels: map[string]string{constants.SyncProvider: providerLabel},
+			ManagedFields: []metav1.ManagedFieldsEntry{{
+				Manager:    manager,
+				Operation:  metav1.ManagedFieldsOperationUpdate,
+				APIVersion: userv1.GroupVersion.String(),
+				FieldsType: "FieldsV1",
+				FieldsV1:   &metav1.FieldsV1{Raw: []byte(fmt.Sprintf(`{"f:metadata":{"f:labels":{".":{},"f:%s":{}}},"f:users":{}}`, constants.SyncProvider))},
+			}},
+		},
+		Users: users,
+	}
+}
+
+// newTestGroupClient returns a client for a cluster containing the groups. Managed fields are returned as they are by
+// the API server so that they can be upgraded
+func newTestGroupClient(t *testing.T, groups ...*userv1.Group) client.Client {
+	t.Helper()
+
+	objects := []client.Object{}
+	for _, group := range groups {
+		objects = append(objects, group)
+	}
+
+	return fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithReturnManagedFields().WithObjects(objects...).Build()
+}
+
+func newTestTarget(name string, secretName string, key string) redhatcopv1beta1.Target {
+	return redhatcopv1beta1.Target{
+		Name:             name,
+		KubeconfigSecret: &redhatcopv1beta1.ObjectRef{Kind: redhatcopv1beta1.SecretMapObjectRefKind, Name: secretName, Namespace: testNamespace, Key: key},
+	}
+}
+
+func TestGetTargetClusters(t *testing.T) {
+	kubeconfigSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "kubeconfig", Namespace: testNamespace}, Data: map[string][]byte{"kubeconfig": []byte(testKubeconfig)}}
+	invalidSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "invalid", Namespace: testNamespace}, Data: map[string][]byte{"kubeconfig": []byte("not a kubeconfig")}}
+
+	r := newTestReconciler(t, kubeconfigSecret, invalidSecret)
+
+	instance := &redhatcopv1beta1.GroupSync{
+		ObjectMeta: metav1.ObjectMeta{Name: "groupsync", Namespace: testNamespace},
+		Spec: redhatcopv1beta1.GroupSyncSpec{
+			Targets: []redhatcopv1beta1.Target{
+				newTestTarget("remote", "kubeconfig", ""),
+				newTestTarget("invalid", "invalid", ""),
+				newTestTarget("missing-secret", "missing", ""),
+				newTestTarget("missing-key", "kubeconfig", "config"),
+			},
+		},
+	}
+
+	targets := r.getTargetClusters(context.TODO(), instance)
+	if len(targets) != 4 {
+		t.Fatalf("getTargetClusters() returned %d targets, expected 4", len(targets))
+	}
+
+	if targets[0].name != "remote" || targets[0].client == nil || len(targets[0].errors) > 0 {
+		t.Errorf("getTargetClusters() target 'remote' client = %v, errors = %v", targets[0].client, targets[0].errors)
+	}
+
+	for _, target := range targets[1:] {
+		if target.client != nil || len(target.errors) != 1 {
+			t.Errorf("getTargetClusters() target '%s' client = %v, errors = %v, expected a single error", target.name, target.client, target.errors)
+		}
+	}
+	if !apierrors.IsNotFound(targets[2].errors[0]) {
+		t.Errorf("getTargetClusters() target 'missing-secret' error = %v, expected not found", targets[2].errors[0])
+	}
+
+	// Clients are reused while the Secret is unchanged
+	cachedTargets := r.getTargetClusters(context.TODO(), instance)
+	if cachedTargets[0].client != targets[0].client {
+		t.Errorf("getTargetClusters() rebuilt the client of an unchanged kubeconfig Secret")
+	}
+
+	// Clients are rebuilt once the Secret changes
+	kubeconfigSecret.Data["kubeconfig"] = []byte(testKubeconfig + "\n")
+	if err := r.GetClient().Update(context.TODO(), kubeconfigSecret); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	updatedTargets := r.getTargetClusters(context.TODO(), instance)
+	if updatedTargets[0].client == nil || updatedTargets[0].client == targets[0].client {
+		t.Errorf("getTargetClusters() did not rebuild the client of an updated kubeconfig Secret")
+	}
+
+	// Clients of targets that could not be reached are not retained
+	namespacedName := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+	if cachedClients := r.getCachedTargetClients(namespacedName); len(cachedClients) != 1 || cachedClients["remote"].client != updatedTargets[0].client {
+		t.Errorf("getTargetClusters() cached clients = %v, expected only target 'remote'", cachedClients)
+	}
+
+	// Clients of removed targets are released
+	instance.Spec.Targets = instance.Spec.Targets[1:]
+	r.getTargetClusters(context.TODO(), instance)
+	if cachedClients, found := r.targetClients[namespacedName]; found {
+		t.Errorf("getTargetClusters() cached clients = %v, expected none", cachedClients)
+	}
+}
+
+func TestSyncTarget(t *testing.T) {
+	tests := []struct {
+		name           string
+		prune          bool
+		expectedGroups map[string][]string
+		expectedPruned int
+	}{
+		{
+			name:  "apply",
+			prune: false,
+			expectedGroups: map[string][]string{
+				"developers": {"jane", "john"},
+				"stale":      {"jim"},
+				"retained":   {"joan"},
+				"other":      {"jack"},
+			},
+		},
+		{
+			name:  "apply and prune",
+			prune: true,
+			expectedGroups: map[string][]string{
+				"developers": {"jane", "john"},
+				"retained":   {"joan"},
+				"other":      {"jack"},
+			},
+			expectedPruned: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t)
+			targetClient := newTestGroupClient(t,
+				newTestGroup("developers", "developers-uid", testProviderLabel, "jane"),
+				newTestGroup("stale", "stale-uid", testProviderLabel, "jim"),
+				newTestGroup("retained", "retained-uid", testProviderLabel, "joan"),
+				newTestGroup("other", "other-uid", "groupsync_other", "jack"),
+			)
+
+			instance := &redhatcopv1beta1.GroupSync{ObjectMeta: metav1.ObjectMeta{Name: "groupsync", Namespace: testNamespace}}
+			target := &targetCluster{name: "remote", client: targetClient}
+			groups := []userv1.Group{*newTestGroup("developers", "", "", "jane", "john")}
+
+			r.syncTarget(context.TODO(), target, instance, &testGroupSyncer{prune: tt.prune}, groups, []string{"retained"}, testProviderLabel, logr.Discard())
+
+			if len(target.errors) > 0 {
+				t.Fatalf("syncTarget() errors = %v", target.errors)
+			}
+			if target.updatedGroups != 1 || target.prunedGroups != tt.expectedPruned {
+				t.Errorf("syncTarget() updated = %d, pruned = %d, expected 1 and %d", target.updatedGroups, target.prunedGroups, tt.expectedPruned)
+			}
+
+			ocpGroups := &userv1.GroupList{}
+			if err := targetClient.List(context.TODO(), ocpGroups); err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			actual := map[string][]string{}
+			for _, group := range ocpGroups.Items {
+				actual[group.Name] = group.Users
+			}
+			if fmt.Sprint(actual) != fmt.Sprint(tt.expectedGroups) {
+				t.Errorf("syncTarget() groups = %v, expected %v", actual, tt.expectedGroups)
+			}
+		})
+	}
+
+	// Targets without a client are skipped
+	r := newTestReconciler(t)
+	target := &targetCluster{name: "invalid", errors: []error{errors.New("invalid kubeconfig")}}
+	r.syncTarget(context.TODO(), target, &redhatcopv1beta1.GroupSync{}, &testGroupSyncer{prune: true}, []userv1.Group{*newTestGroup("developers", "", "")}, nil, testProviderLabel, logr.Discard())
+	if target.updatedGroups != 0 || target.prunedGroups != 0 || len(target.errors) != 1 {
+		t.Errorf("syncTarget() updated a target without a client: %+v", target)
+	}
+}
+
+func TestSetTargetStatus(t *testing.T) {
+	r := newTestReconciler(t)
+
+	lastSyncSuccessTime := metav1.NewTime(metav1.Now().Add(-3600e9))
+	instance := &redhatcopv1beta1.GroupSync{
+		ObjectMeta: metav1.ObjectMeta{Name: "groupsync", Namespace: testNamespace, Generation: 2},
+		Status: redhatcopv1beta1.GroupSyncStatus{
+			Targets: []redhatcopv1beta1.TargetStatus{
+				{Name: "invalid", LastSyncSuccessTime: &lastSyncSuccessTime},
+				{Name: "removed"},
+			},
+		},
+	}
+
+	targets := []*targetCluster{
+		{name: "remote", updatedGroups: 2, prunedGroups: 1},
+		{name: "invalid", errors: []error{errors.New("invalid kubeconfig")}},
+	}
+
+	r.setTargetStatus(instance, targets)
+
+	if len(instance.Status.Targets) != 2 {
+		t.Fatalf("setTargetStatus() targets = %v, expected 2", instance.Status.Targets)
+	}
+
+	remote := instance.Status.Targets[0]
+	if remote.Name != "remote" || remote.GroupsSynchronized != 2 || remote.GroupsPruned != 1 || remote.LastSyncSuccessTime == nil {
+		t.Errorf("setTargetStatus() target 'remote' status = %+v", remote)
+	}
+	if len(remote.Conditions) != 1 || remote.Conditions[0].Type != apis.ReconcileSuccess || remote.Conditions[0].ObservedGeneration != 2 {
+		t.Errorf("setTargetStatus() target 'remote' conditions = %+v", remote.Conditions)
+	}
+
+	invalid := instance.Status.Targets[1]
+	if invalid.Name != "invalid" || invalid.LastSyncSuccessTime == nil || !invalid.LastSyncSuccessTime.Equal(&lastSyncSuccessTime) {
+		t.Errorf("setTargetStatus() target 'invalid' status = %+v, expected last success time to be retained", invalid)
+	}
+	if len(invalid.Conditions) != 1 || invalid.Conditions[0].Type != apis.ReconcileError || invalid.Conditions[0].Message != "invalid kubeconfig" {
+		t.Errorf("setTargetStatus() target 'invalid' conditions = %+v", invalid.Conditions)
+	}
+}
@@ -0,0 +1,128 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	userv1 "github.com/openshift/api/user/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+)
+
+func TestApplyGroupsConflict(t *testing.T) {
+	r := newTestReconciler(t)
+	c := newTestGroupClient(t,
+		newTestGroupManagedBy("kubectl", "developers", "developers-uid", testProviderLabel, "jane"),
+		newTestGroup("stale", "stale-uid", testProviderLabel, "jim"),
+	)
+
+	instance := &redhatcopv1beta1.GroupSync{ObjectMeta: metav1.ObjectMeta{Name: "groupsync", Namespace: testNamespace}}
+	groups := []userv1.Group{
+		*newTestGroup("developers", "", "", "jane", "john"),
+		*newTestGroup("admins", "", "", "joan"),
+	}
+
+	syncedGroups, updatedGroups, errs := r.applyGroups(context.TODO(), c, instance, &testGroupSyncer{}, groups, testProviderLabel)
+
+	if len(errs) != 1 || !apierrors.IsConflict(errs[0]) {
+		t.Fatalf("applyGroups() errors = %v, expected a single conflict", errs)
+	}
+	if updatedGroups != 1 {
+		t.Errorf("applyGroups() updated = %d, expected 1", updatedGroups)
+	}
+
+	// The group that failed to apply retains the UID of the existing group
+	if syncedGroups[0].UID != "developers-uid" {
+		t.Errorf("applyGroups() group 'developers' uid = '%s', expected 'developers-uid'", syncedGroups[0].UID)
+	}
+
+	prunedGroups, err := r.pruneGroups(context.TODO(), c, syncedGroups, nil, testProviderName, testProviderLabel, logr.Discard())
+	if err != nil {
+		t.Fatalf("pruneGroups() error = %v", err)
+	}
+	if prunedGroups != 1 {
+		t.Errorf("pruneGroups() pruned = %d, expected 1", prunedGroups)
+	}
+
+	ocpGroups := &userv1.GroupList{}
+	if err := c.List(context.TODO(), ocpGroups); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	actual := map[string][]string{}
+	for _, group := range ocpGroups.Items {
+		actual[group.Name] = group.Users
+	}
+
+	expected := map[string][]string{"admins": {"joan"}, "developers": {"jane"}}
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf("groups = %v, expected %v", actual, expected)
+	}
+}
+
+func TestUpgradeManagedFields(t *testing.T) {
+	c := newTestGroupClient(t,
+		newTestGroup("legacy", "legacy-uid", testProviderLabel, "jane"),
+		newTestGroupManagedBy("kubectl", "other", "other-uid", testProviderLabel, "john"),
+	)
+
+	tests := []struct {
+		name              string
+		expectedManager   string
+		expectedOperation metav1.ManagedFieldsOperationType
+	}{
+		{name: "legacy", expectedManager: FieldManager, expectedOperation: metav1.ManagedFieldsOperationApply},
+		{name: "other", expectedManager: "kubectl", expectedOperation: metav1.ManagedFieldsOperationUpdate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ocpGroup := &userv1.Group{}
+			if err := c.Get(context.TODO(), types.NamespacedName{Name: tt.name}, ocpGroup); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+
+			if err := upgradeManagedFields(context.TODO(), c, ocpGroup); err != nil {
+				t.Fatalf("upgradeManagedFields() error = %v", err)
+			}
+
+			if err := c.Get(context.TODO(), types.NamespacedName{Name: tt.name}, ocpGroup); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+
+			managedFields := ocpGroup.GetManagedFields()
+			if len(managedFields) != 1 || managedFields[0].Manager != tt.expectedManager || managedFields[0].Operation != tt.expectedOperation {
+				t.Errorf("upgradeManagedFields() managed fields = %+v, expected manager '%s' using %s", managedFields, tt.expectedManager, tt.expectedOperation)
+			}
+		})
+	}
+
+	// Groups written by previous versions of the operator are applied without conflicts
+	r := newTestReconciler(t)
+	instance := &redhatcopv1beta1.GroupSync{ObjectMeta: metav1.ObjectMeta{Name: "groupsync", Namespace: testNamespace}}
+
+	if _, _, errs := r.applyGroups(context.TODO(), c, instance, &testGroupSyncer{}, []userv1.Group{*newTestGroup("legacy", "", "", "jane", "john")}, testProviderLabel); len(errs) > 0 {
+		t.Errorf("applyGroups() errors = %v", errs)
+	}
+}
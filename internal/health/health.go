@@ -0,0 +1,98 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Result represents the outcome of the most recent interaction with a provider
+type Result struct {
+	Error error
+	Time  time.Time
+}
+
+// Tracker records the health of the providers of each GroupSync so that it can be reported by a health endpoint
+type Tracker struct {
+	mu      sync.RWMutex
+	results map[types.NamespacedName]map[string]Result
+}
+
+// NewTracker returns a Tracker without any recorded results
+func NewTracker() *Tracker {
+	return &Tracker{results: map[types.NamespacedName]map[string]Result{}}
+}
+
+// Record stores the outcome of an interaction with a provider of a GroupSync. A nil error marks the provider as healthy
+func (t *Tracker) Record(groupSync types.NamespacedName, provider string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, found := t.results[groupSync]; !found {
+		t.results[groupSync] = map[string]Result{}
+	}
+
+	t.results[groupSync][provider] = Result{Error: err, Time: time.Now()}
+}
+
+// Retain removes the results of providers of a GroupSync that are no longer present. All results of the GroupSync are
+// removed when no providers are provided
+func (t *Tracker) Retain(groupSync types.NamespacedName, providers []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for provider := range t.results[groupSync] {
+		if !slices.Contains(providers, provider) {
+			delete(t.results[groupSync], provider)
+		}
+	}
+
+	if len(t.results[groupSync]) == 0 {
+		delete(t.results, groupSync)
+	}
+}
+
+// Check reports an error listing each provider whose most recent interaction failed. It satisfies healthz.Checker
+func (t *Tracker) Check(_ *http.Request) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	unhealthy := []string{}
+
+	for groupSync, providers := range t.results {
+		for provider, result := range providers {
+			if result.Error != nil {
+				unhealthy = append(unhealthy, fmt.Sprintf("%s/%s", groupSync, provider))
+			}
+		}
+	}
+
+	if len(unhealthy) > 0 {
+		sort.Strings(unhealthy)
+		return fmt.Errorf("unhealthy providers: %s", strings.Join(unhealthy, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package health
+
+import (
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestTracker(t *testing.T) {
+	tracker := NewTracker()
+	groupSync := types.NamespacedName{Namespace: "group-sync-operator", Name: "groupsync"}
+
+	if err := tracker.Check(nil); err != nil {
+		t.Errorf("Check() error = %v, expected no error without results", err)
+	}
+
+	tracker.Record(groupSync, "azure", nil)
+	tracker.Record(groupSync, "ldap", errors.New("connection refused"))
+
+	err := tracker.Check(nil)
+	if err == nil || err.Error() != "unhealthy providers: group-sync-operator/groupsync/ldap" {
+		t.Errorf("Check() error = %v, expected ldap to be unhealthy", err)
+	}
+
+	tracker.Record(groupSync, "ldap", nil)
+	if err := tracker.Check(nil); err != nil {
+		t.Errorf("Check() error = %v, expected ldap to recover", err)
+	}
+
+	tracker.Record(groupSync, "okta", errors.New("unauthorized"))
+	tracker.Retain(groupSync, []string{"azure", "ldap"})
+	if err := tracker.Check(nil); err != nil {
+		t.Errorf("Check() error = %v, expected removed providers to be ignored", err)
+	}
+
+	tracker.Record(groupSync, "ldap", errors.New("connection refused"))
+	tracker.Retain(groupSync, nil)
+	if err := tracker.Check(nil); err != nil {
+		t.Errorf("Check() error = %v, expected removed GroupSyncs to be ignored", err)
+	}
+}
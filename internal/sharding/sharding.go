@@ -0,0 +1,457 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kubeclock "k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	memberLeasePrefix = "group-sync-operator-member-"
+	shardLeasePrefix  = "group-sync-operator-shard-"
+	eventBufferSize   = 1024
+)
+
+// ShardLister lists the objects that may need to be reconciled when ownership of a shard changes
+type ShardLister func(ctx context.Context) ([]client.Object, error)
+
+// Coordinator distributes a fixed number of shards across the live operator replicas.
+//
+// Every replica renews a member Lease to announce that it is alive. The set of live members
+// is sorted and shards are assigned round robin across it, so that the assignment rebalances
+// whenever replicas come and go. A replica only considers a shard owned once it holds the
+// corresponding shard Lease, which guarantees that two replicas never process the same shard
+// at the same time while ownership is being handed over.
+type Coordinator struct {
+	Client        client.Client
+	Reader        client.Reader
+	Log           logr.Logger
+	Namespace     string
+	Identity      string
+	ShardCount    int
+	LeaseDuration time.Duration
+	RetryPeriod   time.Duration
+	List          ShardLister
+
+	clock   kubeclock.WithTicker
+	mu      sync.RWMutex
+	owned   map[int]bool
+	renewed time.Time
+	pending map[int]bool
+	events  chan event.GenericEvent
+}
+
+// Events returns the channel on which objects belonging to newly acquired shards are published
+func (c *Coordinator) Events() <-chan event.GenericEvent {
+	c.init()
+	return c.events
+}
+
+func (c *Coordinator) init() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.events == nil {
+		c.events = make(chan event.GenericEvent, eventBufferSize)
+	}
+	if c.owned == nil {
+		c.owned = map[int]bool{}
+	}
+	if c.clock == nil {
+		c.clock = &kubeclock.RealClock{}
+	}
+}
+
+// NeedLeaderElection allows every replica to take part in sharding
+func (c *Coordinator) NeedLeaderElection() bool {
+	return false
+}
+
+// Start renews the member Lease and rebalances shard ownership until the context is cancelled
+func (c *Coordinator) Start(ctx context.Context) error {
+	c.init()
+
+	c.Log.Info("Starting Shard Coordinator", "Identity", c.Identity, "Shards", c.ShardCount)
+
+	ticker := c.clock.NewTicker(c.RetryPeriod)
+	defer ticker.Stop()
+
+	for {
+		if err := c.reconcileShards(ctx); err != nil {
+			c.Log.Error(err, "Failed to Reconcile Shard Ownership")
+		}
+
+		select {
+		case <-ctx.Done():
+			c.releaseAll(context.Background())
+			return nil
+		case <-ticker.C():
+		}
+	}
+}
+
+// Owns returns whether the object belongs to a shard currently held by this replica
+func (c *Coordinator) Owns(obj client.Object) bool {
+	return c.isOwned(ShardFor(obj, c.ShardCount))
+}
+
+// IsCoordinator returns whether this replica holds the first shard. Tasks that must only run on a single replica while
+// sharding is enabled, in place of leader election, run on the replica for which this returns true
+func (c *Coordinator) IsCoordinator() bool {
+	return c.isOwned(0)
+}
+
+// ShardFor returns the shard an object has been assigned to.
+// An explicit shard label takes precedence over the hash of the namespaced name.
+func ShardFor(obj client.Object, shardCount int) int {
+	if shardCount <= 1 {
+		return 0
+	}
+
+	if value, ok := obj.GetLabels()[constants.ShardLabel]; ok {
+		if shard, err := strconv.Atoi(value); err == nil && shard >= 0 && shard < shardCount {
+			return shard
+		}
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}.String()))
+
+	return int(hash.Sum32() % uint32(shardCount))
+}
+
+// assignShards returns the shards assigned to the member given the list of live members
+func assignShards(identity string, members []string, shardCount int) map[int]bool {
+	assigned := map[int]bool{}
+
+	sort.Strings(members)
+	index := sort.SearchStrings(members, identity)
+
+	if index == len(members) || members[index] != identity {
+		return assigned
+	}
+
+	for shard := 0; shard < shardCount; shard++ {
+		if shard%len(members) == index {
+			assigned[shard] = true
+		}
+	}
+
+	return assigned
+}
+
+func (c *Coordinator) reconcileShards(ctx context.Context) error {
+	// Shard Leases renewed during this reconciliation expire no earlier than the time they started to be renewed
+	renewed := c.clock.Now()
+
+	if err := c.renewLease(ctx, memberLeasePrefix+c.Identity, true); err != nil {
+		c.expireOwnership()
+		return err
+	}
+
+	members, err := c.liveMembers(ctx)
+	if err != nil {
+		c.expireOwnership()
+		return err
+	}
+
+	assigned := assignShards(c.Identity, members, c.ShardCount)
+	owned := map[int]bool{}
+	acquired := map[int]bool{}
+
+	for shard := 0; shard < c.ShardCount; shard++ {
+		leaseName := fmt.Sprintf("%s%d", shardLeasePrefix, shard)
+
+		if !assigned[shard] {
+			if err := c.releaseLease(ctx, leaseName); err != nil {
+				c.Log.Error(err, "Failed to Release Shard", "Shard", shard)
+			}
+			continue
+		}
+
+		held, err := c.acquireLease(ctx, leaseName)
+		if err != nil {
+			c.Log.Error(err, "Failed to Acquire Shard", "Shard", shard)
+			continue
+		}
+
+		if held {
+			owned[shard] = true
+			if !c.isOwned(shard) {
+				acquired[shard] = true
+			}
+		}
+	}
+
+	c.mu.Lock()
+	c.owned = owned
+	c.renewed = renewed
+	c.mu.Unlock()
+
+	if len(acquired) > 0 {
+		c.Log.Info("Acquired Shards", "Shards", sortedShards(acquired), "Owned Shards", sortedShards(owned), "Members", len(members))
+	}
+
+	// Shards whose objects could not all be published previously are retried while they remain owned
+	for shard := range c.pending {
+		if owned[shard] {
+			acquired[shard] = true
+		}
+	}
+
+	c.pending = c.enqueue(ctx, acquired)
+
+	return nil
+}
+
+// isOwned returns whether the shard is held by this replica. Ownership ends once the shard Leases may have expired
+// since they were last renewed, as another replica may then take them over
+func (c *Coordinator) isOwned(shard int) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.owned[shard] && c.clock.Now().Before(c.renewed.Add(c.LeaseDuration))
+}
+
+// expireOwnership gives up the shards held by this replica once their Leases may have expired after failing to
+// renew them
+func (c *Coordinator) expireOwnership() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.owned) == 0 || c.clock.Now().Before(c.renewed.Add(c.LeaseDuration)) {
+		return
+	}
+
+	c.Log.Info("Shard Leases Expired", "Shards", sortedShards(c.owned))
+	c.owned = map[int]bool{}
+}
+
+// enqueue publishes the objects belonging to the shards without blocking so that Lease renewal is never delayed by
+// the consumer of the events. The shards whose objects could not all be published are returned to be retried
+func (c *Coordinator) enqueue(ctx context.Context, shards map[int]bool) map[int]bool {
+	pending := map[int]bool{}
+
+	if c.List == nil || len(shards) == 0 {
+		return pending
+	}
+
+	objects, err := c.List(ctx)
+	if err != nil {
+		c.Log.Error(err, "Failed to List Objects for Acquired Shards")
+		return shards
+	}
+
+	for _, obj := range objects {
+		shard := ShardFor(obj, c.ShardCount)
+		if !shards[shard] || pending[shard] {
+			continue
+		}
+
+		select {
+		case c.events <- event.GenericEvent{Object: obj}:
+		default:
+			pending[shard] = true
+		}
+	}
+
+	if len(pending) > 0 {
+		c.Log.Info("Event Buffer Full, Retrying Shards", "Shards", sortedShards(pending))
+	}
+
+	return pending
+}
+
+// ShardLabelChangedPredicate passes updates that change the shard label of an object so that it is reconciled by the
+// replica owning the shard it was moved to
+func ShardLabelChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+
+			return e.ObjectOld.GetLabels()[constants.ShardLabel] != e.ObjectNew.GetLabels()[constants.ShardLabel]
+		},
+	}
+}
+
+func (c *Coordinator) liveMembers(ctx context.Context) ([]string, error) {
+	leases := &coordinationv1.LeaseList{}
+	if err := c.Reader.List(ctx, leases, client.InNamespace(c.Namespace), client.MatchingLabels{constants.ShardMemberLabel: "true"}); err != nil {
+		return nil, err
+	}
+
+	members := []string{}
+	for _, lease := range leases.Items {
+		if lease.Spec.HolderIdentity != nil && !c.isExpired(&lease) {
+			members = append(members, *lease.Spec.HolderIdentity)
+		}
+	}
+
+	return members, nil
+}
+
+func (c *Coordinator) isExpired(lease *coordinationv1.Lease) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+
+	expiry := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+
+	return c.clock.Now().After(expiry)
+}
+
+// acquireLease takes or renews the lease when it is free, expired or already held by this replica
+func (c *Coordinator) acquireLease(ctx context.Context, name string) (bool, error) {
+	lease := &coordinationv1.Lease{}
+	err := c.Reader.Get(ctx, types.NamespacedName{Name: name, Namespace: c.Namespace}, lease)
+
+	if apierrors.IsNotFound(err) {
+		return true, c.renewLease(ctx, name, false)
+	} else if err != nil {
+		return false, err
+	}
+
+	holder := ptr.Deref(lease.Spec.HolderIdentity, "")
+	if holder != "" && holder != c.Identity && !c.isExpired(lease) {
+		return false, nil
+	}
+
+	if holder != c.Identity {
+		lease.Spec.AcquireTime = &metav1.MicroTime{Time: c.clock.Now()}
+		lease.Spec.LeaseTransitions = ptr.To(ptr.Deref(lease.Spec.LeaseTransitions, 0) + 1)
+	}
+
+	lease.Spec.HolderIdentity = ptr.To(c.Identity)
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(c.LeaseDuration.Seconds()))
+	lease.Spec.RenewTime = &metav1.MicroTime{Time: c.clock.Now()}
+
+	if err := c.Client.Update(ctx, lease); err != nil {
+		if apierrors.IsConflict(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// renewLease creates or renews a lease held by this replica
+func (c *Coordinator) renewLease(ctx context.Context, name string, member bool) error {
+	now := metav1.MicroTime{Time: c.clock.Now()}
+
+	lease := &coordinationv1.Lease{}
+	err := c.Reader.Get(ctx, types.NamespacedName{Name: name, Namespace: c.Namespace}, lease)
+
+	if apierrors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: c.Namespace,
+				Labels:    map[string]string{},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				AcquireTime: &now,
+			},
+		}
+		if member {
+			lease.Labels[constants.ShardMemberLabel] = "true"
+		}
+		lease.Spec.HolderIdentity = ptr.To(c.Identity)
+		lease.Spec.LeaseDurationSeconds = ptr.To(int32(c.LeaseDuration.Seconds()))
+		lease.Spec.RenewTime = &now
+
+		return c.Client.Create(ctx, lease)
+	} else if err != nil {
+		return err
+	}
+
+	lease.Spec.HolderIdentity = ptr.To(c.Identity)
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(c.LeaseDuration.Seconds()))
+	lease.Spec.RenewTime = &now
+
+	return c.Client.Update(ctx, lease)
+}
+
+// releaseLease gives up a lease held by this replica so that another replica can claim it immediately
+func (c *Coordinator) releaseLease(ctx context.Context, name string) error {
+	lease := &coordinationv1.Lease{}
+	err := c.Reader.Get(ctx, types.NamespacedName{Name: name, Namespace: c.Namespace}, lease)
+
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if ptr.Deref(lease.Spec.HolderIdentity, "") != c.Identity {
+		return nil
+	}
+
+	lease.Spec.HolderIdentity = nil
+	lease.Spec.RenewTime = nil
+
+	return c.Client.Update(ctx, lease)
+}
+
+func (c *Coordinator) releaseAll(ctx context.Context) {
+	c.mu.Lock()
+	c.owned = map[int]bool{}
+	c.mu.Unlock()
+
+	for shard := 0; shard < c.ShardCount; shard++ {
+		if err := c.releaseLease(ctx, fmt.Sprintf("%s%d", shardLeasePrefix, shard)); err != nil {
+			c.Log.Error(err, "Failed to Release Shard", "Shard", shard)
+		}
+	}
+
+	if err := c.Client.Delete(ctx, &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Name: memberLeasePrefix + c.Identity, Namespace: c.Namespace}}); err != nil && !apierrors.IsNotFound(err) {
+		c.Log.Error(err, "Failed to Remove Member Lease")
+	}
+}
+
+func sortedShards(shards map[int]bool) []int {
+	result := []int{}
+	for shard := range shards {
+		result = append(result, shard)
+	}
+	sort.Ints(result)
+
+	return result
+}
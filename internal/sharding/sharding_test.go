@@ -0,0 +1,237 @@
+package sharding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+)
+
+func newGroupSync(namespace, name string, labels map[string]string) *redhatcopv1beta1.GroupSync {
+	return &redhatcopv1beta1.GroupSync{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    labels,
+		},
+	}
+}
+
+func TestShardForIsStable(t *testing.T) {
+	groupSync := newGroupSync("group-sync-operator", "azure-groupsync", nil)
+
+	shard := ShardFor(groupSync, 8)
+
+	assert.GreaterOrEqual(t, shard, 0)
+	assert.Less(t, shard, 8)
+	assert.Equal(t, shard, ShardFor(groupSync, 8))
+}
+
+func TestShardForLabel(t *testing.T) {
+	tests := []struct {
+		name     string
+		label    string
+		expected int
+	}{
+		{name: "valid label", label: "3", expected: 3},
+		{name: "label out of range", label: "8", expected: -1},
+		{name: "invalid label", label: "three", expected: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groupSync := newGroupSync("group-sync-operator", "azure-groupsync", map[string]string{constants.ShardLabel: tt.label})
+			hashed := ShardFor(newGroupSync("group-sync-operator", "azure-groupsync", nil), 8)
+
+			if tt.expected == -1 {
+				assert.Equal(t, hashed, ShardFor(groupSync, 8))
+			} else {
+				assert.Equal(t, tt.expected, ShardFor(groupSync, 8))
+			}
+		})
+	}
+}
+
+func TestAssignShards(t *testing.T) {
+	members := []string{"replica-c", "replica-a", "replica-b"}
+
+	assigned := map[int]string{}
+	for _, member := range members {
+		for shard := range assignShards(member, append([]string{}, members...), 7) {
+			_, duplicate := assigned[shard]
+			assert.False(t, duplicate, "shard %d assigned more than once", shard)
+			assigned[shard] = member
+		}
+	}
+
+	assert.Len(t, assigned, 7)
+	assert.Empty(t, assignShards("replica-d", members, 7))
+
+	// Remaining members take over the shards of a replica that went away
+	assert.Len(t, assignShards("replica-a", []string{"replica-a"}, 7), 7)
+}
+
+func newTestCoordinator(fakeClient client.Client, fakeClock *clocktesting.FakeClock, identity string, list ShardLister) *Coordinator {
+	return &Coordinator{
+		Client:        fakeClient,
+		Reader:        fakeClient,
+		Log:           logr.Discard(),
+		Namespace:     "group-sync-operator",
+		Identity:      identity,
+		ShardCount:    4,
+		LeaseDuration: 15 * time.Second,
+		RetryPeriod:   2 * time.Second,
+		List:          list,
+		clock:         fakeClock,
+	}
+}
+
+func ownedShards(c *Coordinator) []int {
+	shards := []int{}
+	for shard := 0; shard < c.ShardCount; shard++ {
+		if c.isOwned(shard) {
+			shards = append(shards, shard)
+		}
+	}
+
+	return shards
+}
+
+func TestCoordinatorRebalancesShards(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+
+	replicaA := newTestCoordinator(fakeClient, fakeClock, "replica-a", nil)
+	replicaB := newTestCoordinator(fakeClient, fakeClock, "replica-b", nil)
+	replicaA.init()
+	replicaB.init()
+
+	// A single replica owns every shard
+	assert.NoError(t, replicaA.reconcileShards(ctx))
+	assert.Equal(t, []int{0, 1, 2, 3}, ownedShards(replicaA))
+
+	// A joining replica waits until the shards assigned to it are released
+	assert.NoError(t, replicaB.reconcileShards(ctx))
+	assert.Empty(t, ownedShards(replicaB))
+
+	assert.NoError(t, replicaA.reconcileShards(ctx))
+	assert.Equal(t, []int{0, 2}, ownedShards(replicaA))
+
+	assert.NoError(t, replicaB.reconcileShards(ctx))
+	assert.Equal(t, []int{1, 3}, ownedShards(replicaB))
+
+	// Only the replica holding the first shard is the coordinator
+	assert.True(t, replicaA.IsCoordinator())
+	assert.False(t, replicaB.IsCoordinator())
+
+	// The shards of a replica that stops renewing its Leases are taken over once they expire
+	fakeClock.Step(20 * time.Second)
+	assert.NoError(t, replicaB.reconcileShards(ctx))
+	assert.Equal(t, []int{0, 1, 2, 3}, ownedShards(replicaB))
+	assert.True(t, replicaB.IsCoordinator())
+
+	lease := &coordinationv1.Lease{}
+	assert.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: "group-sync-operator", Name: shardLeasePrefix + "0"}, lease))
+	assert.Equal(t, "replica-b", *lease.Spec.HolderIdentity)
+}
+
+func TestCoordinatorOwnershipExpires(t *testing.T) {
+	ctx := context.Background()
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+
+	disconnected := false
+	fakeClient := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if disconnected {
+				return errors.New("connection refused")
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+	}).Build()
+
+	coordinator := newTestCoordinator(fakeClient, fakeClock, "replica-a", nil)
+	coordinator.init()
+	groupSync := newGroupSync("group-sync-operator", "azure-groupsync", nil)
+
+	assert.NoError(t, coordinator.reconcileShards(ctx))
+	assert.True(t, coordinator.Owns(groupSync))
+
+	// Shards remain owned while their Leases have not expired, even though they can no longer be renewed
+	disconnected = true
+	fakeClock.Step(10 * time.Second)
+	assert.Error(t, coordinator.reconcileShards(ctx))
+	assert.True(t, coordinator.Owns(groupSync))
+
+	// Shards are given up once their Leases may have been taken over by another replica
+	fakeClock.Step(6 * time.Second)
+	assert.False(t, coordinator.Owns(groupSync))
+	assert.Error(t, coordinator.reconcileShards(ctx))
+	assert.Empty(t, coordinator.owned)
+
+	// Shards are owned again once their Leases are renewed
+	disconnected = false
+	assert.NoError(t, coordinator.reconcileShards(ctx))
+	assert.True(t, coordinator.Owns(groupSync))
+}
+
+func TestCoordinatorEnqueueDoesNotBlock(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+
+	groupSyncs := []client.Object{}
+	for shard := 0; shard < 4; shard++ {
+		groupSyncs = append(groupSyncs, newGroupSync("group-sync-operator", fmt.Sprintf("groupsync-%d", shard), map[string]string{constants.ShardLabel: strconv.Itoa(shard)}))
+	}
+
+	coordinator := newTestCoordinator(fakeClient, fakeClock, "replica-a", func(context.Context) ([]client.Object, error) {
+		return groupSyncs, nil
+	})
+	coordinator.events = make(chan event.GenericEvent, 1)
+	coordinator.init()
+
+	// Lease renewal completes even though the events of most shards do not fit in the buffer
+	assert.NoError(t, coordinator.reconcileShards(ctx))
+	assert.Len(t, coordinator.pending, 3)
+
+	received := []string{}
+	for len(received) < len(groupSyncs) {
+		select {
+		case e := <-coordinator.Events():
+			received = append(received, e.Object.GetName())
+		default:
+			assert.NoError(t, coordinator.reconcileShards(ctx))
+		}
+	}
+
+	assert.ElementsMatch(t, []string{"groupsync-0", "groupsync-1", "groupsync-2", "groupsync-3"}, received)
+	assert.NoError(t, coordinator.reconcileShards(ctx))
+	assert.Empty(t, coordinator.pending)
+}
+
+func TestShardLabelChangedPredicate(t *testing.T) {
+	oldGroupSync := newGroupSync("group-sync-operator", "azure-groupsync", map[string]string{constants.ShardLabel: "1"})
+	movedGroupSync := newGroupSync("group-sync-operator", "azure-groupsync", map[string]string{constants.ShardLabel: "2"})
+
+	assert.True(t, ShardLabelChangedPredicate().Update(event.UpdateEvent{ObjectOld: oldGroupSync, ObjectNew: movedGroupSync}))
+	assert.False(t, ShardLabelChangedPredicate().Update(event.UpdateEvent{ObjectOld: oldGroupSync, ObjectNew: oldGroupSync}))
+	assert.False(t, ShardLabelChangedPredicate().Create(event.CreateEvent{Object: movedGroupSync}))
+}
@@ -0,0 +1,163 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storageversion
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+)
+
+// GroupSyncCRDName is the name of the CustomResourceDefinition for GroupSyncs
+const GroupSyncCRDName = "groupsyncs.redhatcop.redhat.io"
+
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions/status,verbs=get;update;patch
+
+// Migrator rewrites every GroupSync using the storage version of the CustomResourceDefinition
+// and then removes the previous versions from the stored versions of the CustomResourceDefinition,
+// so that older versions can eventually be dropped from the API.
+type Migrator struct {
+	Client client.Client
+	Reader client.Reader
+	Log    logr.Logger
+
+	// Elected restricts the migration to the replica for which it returns true. It is used when sharding is enabled,
+	// as leader election cannot be combined with sharding and every replica would otherwise perform the migration.
+	Elected func() bool
+	// RetryPeriod is the interval at which Elected is checked
+	RetryPeriod time.Duration
+	// Namespaces restricts the migration to the GroupSyncs in the given namespaces. As GroupSyncs in other namespaces
+	// are not migrated, the stored versions of the CustomResourceDefinition are left unchanged.
+	Namespaces []string
+}
+
+// NeedLeaderElection ensures that only a single replica performs the migration
+func (m *Migrator) NeedLeaderElection() bool {
+	return true
+}
+
+// Start performs the migration once. Failures are logged and retried on the next start of the operator.
+func (m *Migrator) Start(ctx context.Context) error {
+	if m.Elected != nil {
+		if err := wait.PollUntilContextCancel(ctx, m.RetryPeriod, true, func(context.Context) (bool, error) {
+			return m.Elected(), nil
+		}); err != nil {
+			return nil
+		}
+	}
+
+	if err := m.migrate(ctx); err != nil {
+		m.Log.Error(err, "Failed to Migrate GroupSync Storage Version")
+	}
+
+	return nil
+}
+
+func (m *Migrator) migrate(ctx context.Context) error {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := m.Reader.Get(ctx, types.NamespacedName{Name: GroupSyncCRDName}, crd); err != nil {
+		return err
+	}
+
+	storageVersion := ""
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			storageVersion = version.Name
+		}
+	}
+
+	if storageVersion != redhatcopv1beta1.GroupVersion.Version {
+		return fmt.Errorf("unexpected storage version '%s'", storageVersion)
+	}
+
+	if len(crd.Status.StoredVersions) == 1 && crd.Status.StoredVersions[0] == storageVersion {
+		m.Log.V(1).Info("GroupSync Storage Version Already Migrated", "Version", storageVersion)
+		return nil
+	}
+
+	m.Log.Info("Migrating GroupSync Storage Version", "Stored Versions", crd.Status.StoredVersions, "Version", storageVersion)
+
+	groupSyncs, err := m.list(ctx)
+	if err != nil {
+		return err
+	}
+
+	// An unchanged update causes the API server to write the object using the current storage version
+	for _, groupSync := range groupSyncs {
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			latest := &redhatcopv1beta1.GroupSync{}
+			if err := m.Reader.Get(ctx, client.ObjectKeyFromObject(&groupSync), latest); err != nil {
+				return err
+			}
+
+			return m.Client.Update(ctx, latest)
+		})
+
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	if len(m.Namespaces) > 0 {
+		m.Log.Info("GroupSync Storage Version Migrated in Namespaces", "Migrated", len(groupSyncs), "Namespaces", m.Namespaces, "Version", storageVersion)
+		return nil
+	}
+
+	crd.Status.StoredVersions = []string{storageVersion}
+	if err := m.Client.Status().Update(ctx, crd); err != nil {
+		return err
+	}
+
+	m.Log.Info("GroupSync Storage Version Migrated", "Migrated", len(groupSyncs), "Version", storageVersion)
+
+	return nil
+}
+
+// list returns the GroupSyncs to migrate
+func (m *Migrator) list(ctx context.Context) ([]redhatcopv1beta1.GroupSync, error) {
+	if len(m.Namespaces) == 0 {
+		groupSyncs := &redhatcopv1beta1.GroupSyncList{}
+		if err := m.Reader.List(ctx, groupSyncs); err != nil {
+			return nil, err
+		}
+
+		return groupSyncs.Items, nil
+	}
+
+	items := []redhatcopv1beta1.GroupSync{}
+	for _, namespace := range m.Namespaces {
+		groupSyncs := &redhatcopv1beta1.GroupSyncList{}
+		if err := m.Reader.List(ctx, groupSyncs, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+
+		items = append(items, groupSyncs.Items...)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,95 @@
+package storageversion
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+)
+
+func newTestMigrator(t *testing.T) (*Migrator, client.Client) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, apiextensionsv1.AddToScheme(scheme))
+	assert.NoError(t, redhatcopv1beta1.AddToScheme(scheme))
+
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: GroupSyncCRDName},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1alpha1"}, {Name: "v1beta1", Storage: true}},
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: []string{"v1alpha1", "v1beta1"}},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(crd).WithStatusSubresource(crd).Build()
+
+	return &Migrator{Client: fakeClient, Reader: fakeClient, Log: logr.Discard(), RetryPeriod: 10 * time.Millisecond}, fakeClient
+}
+
+func storedVersions(t *testing.T, c client.Client) []string {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	assert.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: GroupSyncCRDName}, crd))
+
+	return crd.Status.StoredVersions
+}
+
+func TestMigratorStart(t *testing.T) {
+	migrator, fakeClient := newTestMigrator(t)
+
+	assert.NoError(t, migrator.Start(context.Background()))
+	assert.Equal(t, []string{"v1beta1"}, storedVersions(t, fakeClient))
+}
+
+func TestMigratorWaitsUntilElected(t *testing.T) {
+	migrator, fakeClient := newTestMigrator(t)
+
+	// A replica that is never elected does not migrate
+	migrator.Elected = func() bool { return false }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	assert.NoError(t, migrator.Start(ctx))
+	assert.Equal(t, []string{"v1alpha1", "v1beta1"}, storedVersions(t, fakeClient))
+
+	// The migration is performed once the replica is elected
+	var checks atomic.Int32
+	migrator.Elected = func() bool { return checks.Add(1) > 2 }
+
+	assert.NoError(t, migrator.Start(context.Background()))
+	assert.Equal(t, []string{"v1beta1"}, storedVersions(t, fakeClient))
+	assert.Equal(t, int32(3), checks.Load())
+}
+
+func TestMigratorNamespaces(t *testing.T) {
+	migrator, fakeClient := newTestMigrator(t)
+
+	for _, namespace := range []string{"team-a", "team-b"} {
+		assert.NoError(t, fakeClient.Create(context.Background(), &redhatcopv1beta1.GroupSync{ObjectMeta: metav1.ObjectMeta{Name: "groupsync", Namespace: namespace}}))
+	}
+
+	var updated []string
+	migrator.Client = interceptor.NewClient(fakeClient.(client.WithWatch), interceptor.Funcs{
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			updated = append(updated, obj.GetNamespace())
+			return c.Update(ctx, obj, opts...)
+		},
+	})
+	migrator.Namespaces = []string{"team-a"}
+
+	// GroupSyncs outside of the namespaces are not migrated, so the stored versions are left unchanged
+	assert.NoError(t, migrator.Start(context.Background()))
+	assert.Equal(t, []string{"team-a"}, updated)
+	assert.Equal(t, []string{"v1alpha1", "v1beta1"}, storedVersions(t, fakeClient))
+}
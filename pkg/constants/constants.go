@@ -6,7 +6,12 @@ const (
 	SyncSourceURL     = AnnotationBase + "/sync.source.url"
 	SyncSourceHost    = AnnotationBase + "/sync.source.host"
 	SyncSourceUID     = AnnotationBase + "/sync.source.uid"
+	SyncSourceOrg     = AnnotationBase + "/sync.source.organization"
+	SyncSourceRepo    = AnnotationBase + "/sync.source.repository"
+	SyncMemberExpiry  = AnnotationBase + "/sync.member-expiry"
 	SyncProvider      = AnnotationBase + "/sync-provider"
+	ShardLabel        = AnnotationBase + "/shard"
+	ShardMemberLabel  = AnnotationBase + "/shard-member"
 	HierarchyChildren = "hierarchy_children"
 	HierarchyParent   = "hierarchy_parent"
 	HierarchyParents  = "hierarchy_parents"
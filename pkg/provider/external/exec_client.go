@@ -0,0 +1,66 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	logger = logf.Log.WithName("external_exec_client")
+)
+
+type PluginClient interface {
+	GetGroups(ctx context.Context, request *Request) (*Response, error)
+}
+
+// ExecClient runs a plugin executable, writing the request to its standard input and reading the response from its standard output
+type ExecClient struct {
+	Path    string
+	Args    []string
+	Timeout time.Duration
+}
+
+func (c *ExecClient) GetGroups(ctx context.Context, request *Request) (*Response, error) {
+
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, c.Path, c.Args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	logger.V(1).Info("Executing plugin", "Plugin", c.Path)
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("Plugin '%s' did not complete within %s", c.Path, c.Timeout)
+		}
+		return nil, fmt.Errorf("Plugin '%s' failed: %w: %s", c.Path, err, strings.TrimSpace(stderr.String()))
+	}
+
+	response := &Response{}
+	if err := json.Unmarshal(stdout.Bytes(), response); err != nil {
+		return nil, fmt.Errorf("Invalid response from plugin '%s': %w", c.Path, err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,73 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHelperProcess is executed as the plugin by the tests below
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	defer os.Exit(0)
+
+	switch os.Args[len(os.Args)-1] {
+	case "groups":
+		request := &Request{}
+		if err := json.NewDecoder(os.Stdin).Decode(request); err != nil {
+			fmt.Fprintf(os.Stderr, "invalid request: %v", err)
+			os.Exit(1)
+		}
+		response := Response{Groups: []Group{
+			{Name: request.ProviderName + "-admins", UID: "1", Members: []string{request.Credentials["username"]}},
+		}}
+		json.NewEncoder(os.Stdout).Encode(response)
+	case "fail":
+		fmt.Fprint(os.Stderr, "unable to reach HR system")
+		os.Exit(2)
+	case "invalid":
+		fmt.Fprint(os.Stdout, "not json")
+	case "sleep":
+		time.Sleep(10 * time.Second)
+	}
+}
+
+func newHelperClient(t *testing.T, mode string, timeout time.Duration) *ExecClient {
+	t.Setenv("GO_WANT_HELPER_PROCESS", "1")
+	return &ExecClient{
+		Path:    os.Args[0],
+		Args:    []string{"-test.run=TestHelperProcess", "--", mode},
+		Timeout: timeout,
+	}
+}
+
+func TestGetGroups(t *testing.T) {
+	client := newHelperClient(t, "groups", time.Minute)
+
+	response, err := client.GetGroups(context.TODO(), &Request{
+		ProtocolVersion: ProtocolVersion,
+		ProviderName:    "hr",
+		Credentials:     map[string]string{"username": "jane"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Group{{Name: "hr-admins", UID: "1", Members: []string{"jane"}}}, response.Groups)
+}
+
+func TestGetGroupsFailure(t *testing.T) {
+	_, err := newHelperClient(t, "fail", time.Minute).GetGroups(context.TODO(), &Request{})
+	assert.ErrorContains(t, err, "unable to reach HR system")
+
+	_, err = newHelperClient(t, "invalid", time.Minute).GetGroups(context.TODO(), &Request{})
+	assert.ErrorContains(t, err, "Invalid response")
+
+	_, err = newHelperClient(t, "sleep", 100*time.Millisecond).GetGroups(context.TODO(), &Request{})
+	assert.ErrorContains(t, err, "did not complete")
+}
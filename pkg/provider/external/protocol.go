@@ -0,0 +1,52 @@
+package external
+
+// ProtocolVersion is the version of the protocol spoken between the operator and plugins
+const ProtocolVersion = "v1"
+
+// Request is written as JSON to the standard input of the plugin
+type Request struct {
+	// ProtocolVersion is the version of the protocol used to encode the request
+	ProtocolVersion string `json:"protocolVersion"`
+
+	// ProviderName is the name of the provider within the GroupSync
+	ProviderName string `json:"providerName"`
+
+	// Config contains the configuration values specified for the provider
+	Config map[string]string `json:"config,omitempty"`
+
+	// Credentials contains the data of the credentials secret referenced by the provider
+	Credentials map[string]string `json:"credentials,omitempty"`
+
+	// CaCertificate contains the PEM encoded CA certificate referenced by the provider
+	CaCertificate string `json:"caCertificate,omitempty"`
+
+	// Insecure specifies whether unverified certificates should be accepted
+	Insecure bool `json:"insecure,omitempty"`
+
+	// Groups is the list of groups to synchronize. All groups are synchronized when empty
+	Groups []string `json:"groups,omitempty"`
+}
+
+// Response is read as JSON from the standard output of the plugin
+type Response struct {
+	// Groups contains the groups returned by the plugin
+	Groups []Group `json:"groups"`
+}
+
+// Group represents a group returned by the plugin
+type Group struct {
+	// Name is the name of the group
+	Name string `json:"name"`
+
+	// UID is the unique identifier of the group in the source system
+	UID string `json:"uid,omitempty"`
+
+	// Members are the names of the users within the group
+	Members []string `json:"members,omitempty"`
+
+	// Annotations are added to the synchronized group
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Labels are added to the synchronized group
+	Labels map[string]string `json:"labels,omitempty"`
+}
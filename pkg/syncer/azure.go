@@ -2,10 +2,7 @@ package syncer
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
 	"fmt"
-	"net"
 	"net/url"
 	"reflect"
 	"sort"
@@ -16,14 +13,13 @@ import (
 
 	abstractions "github.com/microsoft/kiota-abstractions-go"
 	userv1 "github.com/openshift/api/user/v1"
-	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
 	"github.com/redhat-cop/group-sync-operator/pkg/constants"
 	"github.com/redhat-cop/operator-utils/pkg/util"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -35,6 +31,7 @@ import (
 	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
 	msgraphcore "github.com/microsoftgraph/msgraph-sdk-go-core"
 	msgroups "github.com/microsoftgraph/msgraph-sdk-go/groups"
+	"github.com/microsoftgraph/msgraph-sdk-go/identitygovernance"
 	graph "github.com/microsoftgraph/msgraph-sdk-go/models"
 
 	"github.com/google/cel-go/cel"
@@ -65,8 +62,8 @@ const (
 
 type AzureSyncer struct {
 	Name              string
-	GroupSync         *redhatcopv1alpha1.GroupSync
-	Provider          *redhatcopv1alpha1.AzureProvider
+	GroupSync         *redhatcopv1beta1.GroupSync
+	Provider          *redhatcopv1beta1.AzureProvider
 	Client            *msgraphsdk.GraphServiceClient
 	ReconcilerBase    util.ReconcilerBase
 	CredentialsSecret *corev1.Secret
@@ -74,7 +71,8 @@ type AzureSyncer struct {
 	CachedGroupUsers  map[string][]*graph.User
 	Context           context.Context
 	Adapter           *msgraphsdk.GraphRequestAdapter
-	CaCertificate     []byte
+	httpClientConfig  *httpClientConfig
+	groupFilter       *groupNameFilter
 	compiledFilter    cel.Program
 }
 
@@ -93,8 +91,7 @@ func (a *AzureSyncer) Validate() error {
 	validationErrors := []error{}
 
 	if a.Provider.CredentialsSecret != nil {
-		credentialsSecret := &corev1.Secret{}
-		err := a.ReconcilerBase.GetClient().Get(a.Context, types.NamespacedName{Name: a.Provider.CredentialsSecret.Name, Namespace: a.Provider.CredentialsSecret.Namespace}, credentialsSecret)
+		credentialsSecret, err := getCredentialsSecret(a.Context, a.ReconcilerBase.GetClient(), a.GroupSync, a.Provider.CredentialsSecret)
 
 		if err != nil {
 			validationErrors = append(validationErrors, err)
@@ -123,29 +120,17 @@ func (a *AzureSyncer) Validate() error {
 		}
 	}
 
-	providerCaResource := determineFromDeprecatedObjectRef(a.Provider.Ca, a.Provider.CaSecret)
-	if providerCaResource != nil {
-
-		caResource, err := getObjectRefData(a.Context, a.ReconcilerBase.GetClient(), providerCaResource)
-
-		if err != nil {
-			validationErrors = append(validationErrors, err)
-		}
-
-		var resourceCaKey string
-		if providerCaResource.Key != "" {
-			resourceCaKey = providerCaResource.Key
-		} else {
-			resourceCaKey = defaultResourceCaKey
-		}
-
-		// Certificate key validation
-		if _, found := caResource[resourceCaKey]; !found {
-			validationErrors = append(validationErrors, fmt.Errorf("Could not find '%s' key in %s '%s' in namespace '%s'", resourceCaKey, providerCaResource.Kind, providerCaResource.Name, providerCaResource.Namespace))
-		}
+	httpClientConfig, err := newHTTPClientConfig(a.Context, a.ReconcilerBase.GetClient(), a.GroupSync, a.Provider.ProviderBase, a.Provider.HTTPClientOptions)
+	if err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+	a.httpClientConfig = httpClientConfig
 
-		a.CaCertificate = caResource[resourceCaKey]
+	groupFilter, err := newGroupNameFilter(a.Provider.Groups, a.Provider.GroupPatterns)
+	if err != nil {
+		validationErrors = append(validationErrors, err)
 	}
+	a.groupFilter = groupFilter
 
 	return utilerrors.NewAggregate(validationErrors)
 
@@ -153,48 +138,12 @@ func (a *AzureSyncer) Validate() error {
 
 func (a *AzureSyncer) Bind() error {
 
-	var httpClient *nethttp.Client
-
-	dialer := &net.Dialer{
-		Timeout:   30 * time.Second,
-		KeepAlive: 30 * time.Second,
-	}
-
-	defaultTransport := &nethttp.Transport{
-		Proxy:                 nethttp.ProxyFromEnvironment,
-		DialContext:           dialer.DialContext,
-		ForceAttemptHTTP2:     true,
-		MaxIdleConns:          100,
-		IdleConnTimeout:       90 * time.Second,
-		TLSHandshakeTimeout:   10 * time.Second,
-		ExpectContinueTimeout: 1 * time.Second,
-	}
-
-	if a.Provider.Insecure || len(a.CaCertificate) > 0 {
-
-		httpClient = kiota.GetDefaultClient()
-
-		var tlsConfig *tls.Config
-
-		if a.Provider.Insecure {
-			tlsConfig = &tls.Config{InsecureSkipVerify: true}
-		} else {
-			if len(a.CaCertificate) > 0 {
-
-				tlsConfig = &tls.Config{}
-				if tlsConfig.RootCAs == nil {
-					tlsConfig.RootCAs = x509.NewCertPool()
-				}
-
-				tlsConfig.RootCAs.AppendCertsFromPEM(a.CaCertificate)
-
-			}
-		}
-
-		defaultTransport.TLSClientConfig = tlsConfig
-
-		httpClient.Transport = kiota.NewCustomTransportWithParentTransport(defaultTransport)
+	defaultTransport := a.httpClientConfig.transport()
 
+	httpClient := kiota.GetDefaultClient()
+	httpClient.Transport = kiota.NewCustomTransportWithParentTransport(defaultTransport)
+	if a.httpClientConfig.timeout > 0 {
+		httpClient.Timeout = a.httpClientConfig.timeout
 	}
 
 	var cred azcore.TokenCredential
@@ -202,6 +151,7 @@ func (a *AzureSyncer) Bind() error {
 
 	httpTransport := &nethttp.Client{
 		Transport: defaultTransport,
+		Timeout:   a.httpClientConfig.timeout,
 	}
 
 	tenantID, _ := getSecretOrEnvValue(a.CredentialsSecret, TenantID)
@@ -401,7 +351,7 @@ func (a *AzureSyncer) Sync() ([]userv1.Group, error) {
 			continue
 		}
 
-		if !isGroupAllowed(*groupName, a.Provider.Groups) {
+		if !a.groupFilter.allows(*groupName) {
 			continue
 		}
 
@@ -431,7 +381,7 @@ func (a *AzureSyncer) Sync() ([]userv1.Group, error) {
 		ocpGroup.GetAnnotations()[constants.SyncSourceHost] = azureURL.Host
 		ocpGroup.GetAnnotations()[constants.SyncSourceUID] = *group.DirectoryObject.GetId()
 
-		groupMembers, err := a.listGroupMembers(group.DirectoryObject.GetId())
+		groupMembers, groupMemberIDs, err := a.listGroupMembers(group.DirectoryObject.GetId())
 
 		if err != nil {
 			azureLogger.Error(err, "Failed to get Group members for Group", "Group", group.GetDisplayName(), "Provider", a.Name)
@@ -442,6 +392,13 @@ func (a *AzureSyncer) Sync() ([]userv1.Group, error) {
 			ocpGroup.Users = append(ocpGroup.Users, groupMember)
 		}
 
+		if a.Provider.PrivilegedAccessExpiry {
+			if err := a.setPrivilegedAccessExpiry(&ocpGroup, *group.DirectoryObject.GetId(), groupMemberIDs); err != nil {
+				azureLogger.Error(err, "Failed to get Privileged Access Assignments for Group", "Group", group.GetDisplayName(), "Provider", a.Name)
+				return nil, err
+			}
+		}
+
 		ocpGroups = append(ocpGroups, ocpGroup)
 
 	}
@@ -454,16 +411,23 @@ func (a *AzureSyncer) GetProviderName() string {
 	return a.Name
 }
 
-func (a *AzureSyncer) listGroupMembers(groupID *string) ([]string, error) {
+// listGroupMembers returns the user names of the transitive members of a group along with the user name of each
+// member keyed by the ID of the user
+func (a *AzureSyncer) listGroupMembers(groupID *string) ([]string, map[string]string, error) {
 	var groupMembers []string
 	var selectParameter []string
+	groupMemberIDs := map[string]string{}
 
-	if a.Provider.UserNameAttributes != nil {
-		selectParameter = *a.Provider.UserNameAttributes
+	if len(a.Provider.UserNameAttributes) > 0 {
+		selectParameter = append([]string{}, a.Provider.UserNameAttributes...)
 	} else {
 		selectParameter = []string{GraphUserNameAttribute}
 	}
 
+	if a.Provider.PrivilegedAccessExpiry {
+		selectParameter = append(selectParameter, GraphID)
+	}
+
 	queryParameters := msgroups.ItemTransitiveMembersGraphUserRequestBuilderGetQueryParameters{
 		Select: selectParameter,
 		Top:    &azurePageSize,
@@ -478,7 +442,7 @@ func (a *AzureSyncer) listGroupMembers(groupID *string) ([]string, error) {
 	memberRequest, err := a.Client.Groups().ByGroupId(*groupID).TransitiveMembers().GraphUser().Get(a.Context, &transitiveMembersConfiguration)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	for {
@@ -486,6 +450,9 @@ func (a *AzureSyncer) listGroupMembers(groupID *string) ([]string, error) {
 		for _, member := range memberRequest.GetValue() {
 			if username, found := a.getUsernameForUser(member); found {
 				groupMembers = append(groupMembers, fmt.Sprintf("%v", username))
+				if member.GetId() != nil {
+					groupMemberIDs[*member.GetId()] = fmt.Sprintf("%v", username)
+				}
 			}
 		}
 
@@ -499,7 +466,7 @@ func (a *AzureSyncer) listGroupMembers(groupID *string) ([]string, error) {
 
 			if err != nil {
 				azureLogger.Error(err, "Failed to get iterate over group members", "Provider", a.Name, "Group ID", groupID)
-				return nil, err
+				return nil, nil, err
 			}
 		} else {
 			break
@@ -507,19 +474,74 @@ func (a *AzureSyncer) listGroupMembers(groupID *string) ([]string, error) {
 
 	}
 
-	return groupMembers, nil
+	return groupMembers, groupMemberIDs, nil
+
+}
+
+// setPrivilegedAccessExpiry records the end time of the active Privileged Identity Management assignments of the
+// members of a group. Members holding a permanent assignment, or a membership that is not managed using Privileged
+// Identity Management, do not expire
+func (a *AzureSyncer) setPrivilegedAccessExpiry(ocpGroup *userv1.Group, groupID string, groupMemberIDs map[string]string) error {
+
+	filter := fmt.Sprintf("groupId eq '%s' and accessId eq 'member'", groupID)
+	requestConfiguration := &identitygovernance.PrivilegedAccessGroupAssignmentScheduleInstancesRequestBuilderGetRequestConfiguration{
+		QueryParameters: &identitygovernance.PrivilegedAccessGroupAssignmentScheduleInstancesRequestBuilderGetQueryParameters{
+			Filter: &filter,
+		},
+	}
+
+	instancesRequest, err := a.Client.IdentityGovernance().PrivilegedAccess().Group().AssignmentScheduleInstances().Get(a.Context, requestConfiguration)
+	if err != nil {
+		return err
+	}
+
+	pageIterator, err := msgraphcore.NewPageIterator[*graph.PrivilegedAccessGroupAssignmentScheduleInstance](instancesRequest, a.Adapter, graph.CreatePrivilegedAccessGroupAssignmentScheduleInstanceCollectionResponseFromDiscriminatorValue)
+	if err != nil {
+		return err
+	}
+
+	memberExpiry := map[string]time.Time{}
+	permanentMembers := map[string]bool{}
+
+	err = pageIterator.Iterate(a.Context, func(instance *graph.PrivilegedAccessGroupAssignmentScheduleInstance) bool {
+		if instance.GetPrincipalId() == nil {
+			return true
+		}
+
+		username, found := groupMemberIDs[*instance.GetPrincipalId()]
+		if !found {
+			return true
+		}
+
+		// Members remain until their latest assignment ends
+		if instance.GetEndDateTime() == nil {
+			permanentMembers[username] = true
+		} else if expiry, found := memberExpiry[username]; !found || instance.GetEndDateTime().After(expiry) {
+			memberExpiry[username] = instance.GetEndDateTime().UTC()
+		}
+
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for username := range permanentMembers {
+		delete(memberExpiry, username)
+	}
 
+	return setMemberExpiry(ocpGroup, memberExpiry)
 }
 
 func (a *AzureSyncer) getUsernameForUser(user graph.Userable) (string, bool) {
 
 	userValue := reflect.ValueOf(user)
 
-	if a.Provider.UserNameAttributes == nil {
+	if len(a.Provider.UserNameAttributes) == 0 {
 		return a.isUsernamePresent(userValue, GraphUserNameAttribute)
 	}
 
-	for _, usernameAttribute := range *a.Provider.UserNameAttributes {
+	for _, usernameAttribute := range a.Provider.UserNameAttributes {
 
 		username, found := a.isUsernamePresent(userValue, usernameAttribute)
 
@@ -555,13 +577,13 @@ func (a *AzureSyncer) GetPrune() bool {
 	return a.Provider.Prune
 }
 
-func getAuthorityHost(authorityHost *string) string {
+func getAuthorityHost(authorityHost string) string {
 
-	if authorityHost == nil {
+	if authorityHost == "" {
 		return cloud.AzurePublic.ActiveDirectoryAuthorityHost
 
 	} else {
-		return *authorityHost
+		return authorityHost
 	}
 
 }
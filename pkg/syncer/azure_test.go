@@ -1,10 +1,17 @@
 package syncer
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/microsoft/kiota-abstractions-go/authentication"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
 	graph "github.com/microsoftgraph/msgraph-sdk-go/models"
-	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
 )
 
 // Helper function to create a pointer to a string
@@ -59,7 +66,7 @@ func TestCompileClientFilter(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			syncer := &AzureSyncer{
-				Provider: &redhatcopv1alpha1.AzureProvider{
+				Provider: &redhatcopv1beta1.AzureProvider{
 					ClientFilter: tt.filter,
 				},
 			}
@@ -99,7 +106,7 @@ func TestProbeClientFilter(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			syncer := &AzureSyncer{
-				Provider: &redhatcopv1alpha1.AzureProvider{
+				Provider: &redhatcopv1beta1.AzureProvider{
 					ClientFilter: tt.filter,
 				},
 			}
@@ -226,7 +233,7 @@ func TestEvaluateClientFilter(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			syncer := &AzureSyncer{
-				Provider: &redhatcopv1alpha1.AzureProvider{
+				Provider: &redhatcopv1beta1.AzureProvider{
 					ClientFilter: tt.filter,
 				},
 			}
@@ -318,3 +325,74 @@ func TestExtractGroupFieldsWithNilValues(t *testing.T) {
 		t.Errorf("mailNickname should be empty string when not set, got %v", mailNickname)
 	}
 }
+
+func TestAzureSyncPrivilegedAccessExpiry(t *testing.T) {
+	instancesFilter := ""
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "g1", "displayName": "developers"}]}`)
+	})
+	mux.HandleFunc("/v1.0/groups/g1/transitiveMembers/graph.user", func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Query().Get("$select"), "id") {
+			t.Errorf("unexpected member selection '%s'", r.URL.Query().Get("$select"))
+		}
+		fmt.Fprint(w, `{"value": [
+			{"id": "u1", "userPrincipalName": "jane@example.com"},
+			{"id": "u2", "userPrincipalName": "john@example.com"},
+			{"id": "u3", "userPrincipalName": "jim@example.com"},
+			{"id": "u4", "userPrincipalName": "joan@example.com"}
+		]}`)
+	})
+	mux.HandleFunc("/v1.0/identityGovernance/privilegedAccess/group/assignmentScheduleInstances", func(w http.ResponseWriter, r *http.Request) {
+		instancesFilter = r.URL.Query().Get("$filter")
+		fmt.Fprint(w, `{"value": [
+			{"id": "a1", "groupId": "g1", "principalId": "u1", "accessId": "member", "assignmentType": "activated", "endDateTime": "2030-01-01T00:00:00Z"},
+			{"id": "a2", "groupId": "g1", "principalId": "u2", "accessId": "member", "assignmentType": "assigned"},
+			{"id": "a3", "groupId": "g1", "principalId": "u3", "accessId": "member", "assignmentType": "activated", "endDateTime": "2030-01-01T00:00:00Z"},
+			{"id": "a4", "groupId": "g1", "principalId": "u3", "accessId": "member", "assignmentType": "assigned", "endDateTime": "2031-01-01T00:00:00Z"}
+		]}`)
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		mux.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	adapter, err := msgraphsdk.NewGraphRequestAdapterWithParseNodeFactoryAndSerializationWriterFactoryAndHttpClient(&authentication.AnonymousAuthenticationProvider{}, nil, nil, server.Client())
+	if err != nil {
+		t.Fatalf("unable to create request adapter: %v", err)
+	}
+	adapter.SetBaseUrl(server.URL + "/v1.0")
+
+	groupFilter, _ := newGroupNameFilter(nil, nil)
+
+	azureSyncer := &AzureSyncer{
+		Name:        "azure",
+		Provider:    &redhatcopv1beta1.AzureProvider{PrivilegedAccessExpiry: true},
+		Adapter:     adapter,
+		Client:      msgraphsdk.NewGraphServiceClient(adapter),
+		groupFilter: groupFilter,
+	}
+	azureSyncer.Init()
+
+	groups, err := azureSyncer.Sync()
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	if instancesFilter != "groupId eq 'g1' and accessId eq 'member'" {
+		t.Errorf("Sync() requested assignments using filter '%s'", instancesFilter)
+	}
+
+	if len(groups) != 1 || fmt.Sprint(groups[0].Users) != "[jane@example.com john@example.com jim@example.com joan@example.com]" {
+		t.Fatalf("Sync() = %v, expected a single group containing every member", groups)
+	}
+
+	// Members without an assignment or holding a permanent assignment do not expire
+	expectedExpiry := `{"jane@example.com":"2030-01-01T00:00:00Z","jim@example.com":"2031-01-01T00:00:00Z"}`
+	if groups[0].Annotations[constants.SyncMemberExpiry] != expectedExpiry {
+		t.Errorf("Sync() member expiry = %s, expected %s", groups[0].Annotations[constants.SyncMemberExpiry], expectedExpiry)
+	}
+}
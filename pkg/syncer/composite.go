@@ -0,0 +1,164 @@
+package syncer
+
+import (
+	"fmt"
+
+	userv1 "github.com/openshift/api/user/v1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	compositeLogger = logf.Log.WithName("syncer_composite")
+)
+
+// CompositeSyncer produces a group from the groups synchronized by the providers of a GroupSync
+type CompositeSyncer struct {
+	Name      string
+	GroupSync *redhatcopv1beta1.GroupSync
+	Composite *redhatcopv1beta1.CompositeGroup
+	// ProviderGroups contains the groups synchronized by each provider, keyed by provider name.
+	// Providers that failed to synchronize must not be present
+	ProviderGroups map[string][]userv1.Group
+	// LimitViolations contains the violations of the limits of the providers. Groups that were truncated must not be
+	// referenced as they do not contain every member
+	LimitViolations []redhatcopv1beta1.LimitViolation
+}
+
+func (c *CompositeSyncer) Init() bool {
+	return false
+}
+
+func (c *CompositeSyncer) Validate() error {
+
+	validationErrors := []error{}
+
+	providerNames := sets.New[string]()
+	for _, provider := range c.GroupSync.Spec.Providers {
+		providerNames.Insert(provider.Name)
+	}
+
+	if providerNames.Has(c.Name) {
+		validationErrors = append(validationErrors, fmt.Errorf("Composite '%s' must not have the same name as a provider", c.Name))
+	}
+
+	composites := 0
+	for _, composite := range c.GroupSync.Spec.Composites {
+		if composite.Name == c.Name {
+			composites++
+		}
+	}
+	if composites > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("Composite '%s' is defined more than once", c.Name))
+	}
+
+	if len(c.Composite.Include) == 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("Composite '%s' must include at least one group", c.Name))
+	}
+
+	for _, groupRef := range c.getGroupRefs() {
+		if !providerNames.Has(groupRef.Provider) {
+			validationErrors = append(validationErrors, fmt.Errorf("Composite '%s' references unknown provider '%s'", c.Name, groupRef.Provider))
+		}
+	}
+
+	return utilerrors.NewAggregate(validationErrors)
+}
+
+func (c *CompositeSyncer) Bind() error {
+	return nil
+}
+
+func (c *CompositeSyncer) Sync() ([]userv1.Group, error) {
+
+	members := sets.New[string]()
+
+	for _, groupRef := range c.Composite.Include {
+		users, err := c.getGroupUsers(groupRef)
+		if err != nil {
+			return nil, err
+		}
+		members.Insert(users...)
+	}
+
+	for _, groupRef := range c.Composite.Intersect {
+		users, err := c.getGroupUsers(groupRef)
+		if err != nil {
+			return nil, err
+		}
+		members = members.Intersection(sets.New(users...))
+	}
+
+	for _, groupRef := range c.Composite.Exclude {
+		users, err := c.getGroupUsers(groupRef)
+		if err != nil {
+			return nil, err
+		}
+		members.Delete(users...)
+	}
+
+	users := sets.List(members)
+
+	compositeLogger.Info("Composed group", "Composite", c.Name, "Members", len(users))
+
+	return []userv1.Group{
+		{
+			TypeMeta: v1.TypeMeta{
+				Kind:       "Group",
+				APIVersion: userv1.GroupVersion.String(),
+			},
+			ObjectMeta: v1.ObjectMeta{
+				Name:        c.Name,
+				Annotations: map[string]string{},
+				Labels:      map[string]string{},
+			},
+			Users: users,
+		},
+	}, nil
+}
+
+func (c *CompositeSyncer) GetProviderName() string {
+	return c.Name
+}
+
+func (c *CompositeSyncer) GetPrune() bool {
+	return c.Composite.Prune
+}
+
+// getGroupUsers returns the members of a group synchronized by a provider. An error is returned when the provider
+// did not complete synchronization, did not return the group or truncated the group so that members are never
+// granted or removed based on partial results
+func (c *CompositeSyncer) getGroupUsers(groupRef redhatcopv1beta1.CompositeGroupRef) ([]string, error) {
+
+	groups, found := c.ProviderGroups[groupRef.Provider]
+	if !found {
+		return nil, fmt.Errorf("Composite '%s': provider '%s' did not complete synchronization", c.Name, groupRef.Provider)
+	}
+
+	for _, violation := range c.LimitViolations {
+		if violation.Provider == groupRef.Provider && violation.Group == groupRef.Group && violation.Policy == redhatcopv1beta1.TruncateMemberLimitPolicy {
+			return nil, fmt.Errorf("Composite '%s': group '%s' of provider '%s' was truncated to a maximum of %d members", c.Name, groupRef.Group, groupRef.Provider, violation.Limit)
+		}
+	}
+
+	for _, group := range groups {
+		if group.Name == groupRef.Group {
+			return group.Users, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Composite '%s': group '%s' was not synchronized by provider '%s'", c.Name, groupRef.Group, groupRef.Provider)
+}
+
+func (c *CompositeSyncer) getGroupRefs() []redhatcopv1beta1.CompositeGroupRef {
+
+	groupRefs := []redhatcopv1beta1.CompositeGroupRef{}
+	groupRefs = append(groupRefs, c.Composite.Include...)
+	groupRefs = append(groupRefs, c.Composite.Intersect...)
+	groupRefs = append(groupRefs, c.Composite.Exclude...)
+
+	return groupRefs
+}
@@ -0,0 +1,124 @@
+package syncer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	userv1 "github.com/openshift/api/user/v1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCompositeSyncer(t *testing.T) {
+	providerGroups := map[string][]userv1.Group{
+		"azure": {{ObjectMeta: metav1.ObjectMeta{Name: "engineering"}, Users: []string{"jane", "john", "jim"}}},
+		"ldap":  {{ObjectMeta: metav1.ObjectMeta{Name: "contractors"}, Users: []string{"joe", "jim"}}},
+		"okta":  {{ObjectMeta: metav1.ObjectMeta{Name: "suspended"}, Users: []string{"john"}}, {ObjectMeta: metav1.ObjectMeta{Name: "terminated"}, Users: []string{"jane"}}},
+	}
+
+	// The terminated group was truncated from its members jane and jim
+	limitViolations := []redhatcopv1beta1.LimitViolation{{Provider: "okta", Group: "terminated", Count: 2, Limit: 1, Policy: redhatcopv1beta1.TruncateMemberLimitPolicy}}
+
+	tests := []struct {
+		name          string
+		composite     redhatcopv1beta1.CompositeGroup
+		expected      []string
+		expectedError string
+	}{
+		{
+			name: "union with exclusion",
+			composite: redhatcopv1beta1.CompositeGroup{
+				Include: []redhatcopv1beta1.CompositeGroupRef{{Provider: "azure", Group: "engineering"}, {Provider: "ldap", Group: "contractors"}},
+				Exclude: []redhatcopv1beta1.CompositeGroupRef{{Provider: "okta", Group: "suspended"}},
+			},
+			expected: []string{"jane", "jim", "joe"},
+		},
+		{
+			name: "intersection",
+			composite: redhatcopv1beta1.CompositeGroup{
+				Include:   []redhatcopv1beta1.CompositeGroupRef{{Provider: "azure", Group: "engineering"}},
+				Intersect: []redhatcopv1beta1.CompositeGroupRef{{Provider: "ldap", Group: "contractors"}},
+			},
+			expected: []string{"jim"},
+		},
+		{
+			name: "group not synchronized",
+			composite: redhatcopv1beta1.CompositeGroup{
+				Include: []redhatcopv1beta1.CompositeGroupRef{{Provider: "azure", Group: "missing"}},
+			},
+			expectedError: "group 'missing' was not synchronized by provider 'azure'",
+		},
+		{
+			name: "provider did not complete synchronization",
+			composite: redhatcopv1beta1.CompositeGroup{
+				Include: []redhatcopv1beta1.CompositeGroupRef{{Provider: "azure", Group: "engineering"}},
+				Exclude: []redhatcopv1beta1.CompositeGroupRef{{Provider: "gitlab", Group: "suspended"}},
+			},
+			expectedError: "provider 'gitlab' did not complete synchronization",
+		},
+		{
+			name: "exclusion of truncated group",
+			composite: redhatcopv1beta1.CompositeGroup{
+				Include: []redhatcopv1beta1.CompositeGroupRef{{Provider: "azure", Group: "engineering"}},
+				Exclude: []redhatcopv1beta1.CompositeGroupRef{{Provider: "okta", Group: "terminated"}},
+			},
+			expectedError: "group 'terminated' of provider 'okta' was truncated to a maximum of 1 members",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compositeSyncer := &CompositeSyncer{
+				Name:            "composite",
+				Composite:       tt.composite.DeepCopy(),
+				ProviderGroups:  providerGroups,
+				LimitViolations: limitViolations,
+			}
+
+			groups, err := compositeSyncer.Sync()
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("Sync() error = %v, expected to contain '%s'", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Sync() error = %v", err)
+			}
+
+			if len(groups) != 1 || groups[0].Name != "composite" {
+				t.Fatalf("Sync() groups = %v, expected a single group named 'composite'", groups)
+			}
+
+			if fmt.Sprint(groups[0].Users) != fmt.Sprint(tt.expected) {
+				t.Errorf("Sync() users = %v, expected %v", groups[0].Users, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompositeSyncerValidation(t *testing.T) {
+	groupSync := &redhatcopv1beta1.GroupSync{
+		Spec: redhatcopv1beta1.GroupSyncSpec{
+			Providers: []redhatcopv1beta1.Provider{{Name: "azure"}},
+			Composites: []redhatcopv1beta1.CompositeGroup{
+				{Name: "azure", Include: []redhatcopv1beta1.CompositeGroupRef{{Provider: "ldap", Group: "contractors"}}},
+				{Name: "azure"},
+			},
+		},
+	}
+
+	compositeSyncer := &CompositeSyncer{Name: "azure", GroupSync: groupSync, Composite: &groupSync.Spec.Composites[0]}
+
+	err := compositeSyncer.Validate()
+	if err == nil {
+		t.Fatalf("Validate() expected an error")
+	}
+
+	for _, expected := range []string{"same name as a provider", "defined more than once", "unknown provider 'ldap'"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Validate() error = %v, expected to contain '%s'", err, expected)
+		}
+	}
+}
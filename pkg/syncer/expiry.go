@@ -0,0 +1,95 @@
+package syncer
+
+import (
+	"encoding/json"
+	"time"
+
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+)
+
+// getMemberExpiry returns the times at which memberships in a group expire, keyed by user
+func getMemberExpiry(group userv1.Group) (map[string]time.Time, error) {
+
+	memberExpiry := map[string]time.Time{}
+
+	if value, found := group.Annotations[constants.SyncMemberExpiry]; found {
+		if err := json.Unmarshal([]byte(value), &memberExpiry); err != nil {
+			return nil, err
+		}
+	}
+
+	return memberExpiry, nil
+}
+
+// setMemberExpiry records the times at which memberships in a group expire, keyed by user
+func setMemberExpiry(group *userv1.Group, memberExpiry map[string]time.Time) error {
+
+	if len(memberExpiry) == 0 {
+		delete(group.Annotations, constants.SyncMemberExpiry)
+		return nil
+	}
+
+	data, err := json.Marshal(memberExpiry)
+	if err != nil {
+		return err
+	}
+
+	if group.Annotations == nil {
+		group.Annotations = map[string]string{}
+	}
+	group.Annotations[constants.SyncMemberExpiry] = string(data)
+
+	return nil
+}
+
+// RemoveExpiredMembers removes the members of each group whose membership expired before now so that access is
+// revoked even when a provider has not yet processed the expiration. The time of the next expiration is returned
+func RemoveExpiredMembers(groups []userv1.Group, now time.Time) (*time.Time, error) {
+
+	var nextExpiry *time.Time
+
+	for i := range groups {
+		group := &groups[i]
+
+		memberExpiry, err := getMemberExpiry(*group)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(memberExpiry) == 0 {
+			continue
+		}
+
+		users := []string{}
+		for _, user := range group.Users {
+			expiry, found := memberExpiry[user]
+
+			if found && !expiry.After(now) {
+				continue
+			}
+
+			if found && (nextExpiry == nil || expiry.Before(*nextExpiry)) {
+				nextExpiry = &expiry
+			}
+
+			users = append(users, user)
+		}
+
+		// Only upcoming expirations of current members are recorded
+		upcomingExpiry := map[string]time.Time{}
+		for _, user := range users {
+			if expiry, found := memberExpiry[user]; found {
+				upcomingExpiry[user] = expiry
+			}
+		}
+
+		group.Users = users
+
+		if err := setMemberExpiry(group, upcomingExpiry); err != nil {
+			return nil, err
+		}
+	}
+
+	return nextExpiry, nil
+}
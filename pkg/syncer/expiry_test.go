@@ -0,0 +1,58 @@
+package syncer
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRemoveExpiredMembers(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	groups := []userv1.Group{
+		{ObjectMeta: metav1.ObjectMeta{Name: "admins"}, Users: []string{"jane", "john", "jim"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "developers"}, Users: []string{"joe"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "operators"}, Users: []string{"jill"}},
+	}
+
+	if err := setMemberExpiry(&groups[0], map[string]time.Time{"john": now.Add(-time.Hour), "jim": now.Add(48 * time.Hour)}); err != nil {
+		t.Fatalf("setMemberExpiry() error = %v", err)
+	}
+	if err := setMemberExpiry(&groups[1], map[string]time.Time{"joe": now.Add(24 * time.Hour)}); err != nil {
+		t.Fatalf("setMemberExpiry() error = %v", err)
+	}
+	if err := setMemberExpiry(&groups[2], map[string]time.Time{"jill": now}); err != nil {
+		t.Fatalf("setMemberExpiry() error = %v", err)
+	}
+
+	nextExpiry, err := RemoveExpiredMembers(groups, now)
+	if err != nil {
+		t.Fatalf("RemoveExpiredMembers() error = %v", err)
+	}
+
+	if nextExpiry == nil || !nextExpiry.Equal(now.Add(24*time.Hour)) {
+		t.Errorf("RemoveExpiredMembers() next expiry = %v, expected %v", nextExpiry, now.Add(24*time.Hour))
+	}
+
+	actual := map[string][]string{}
+	for _, group := range groups {
+		actual[group.Name] = group.Users
+	}
+
+	expected := map[string][]string{"admins": {"jane", "jim"}, "developers": {"joe"}, "operators": {}}
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf("RemoveExpiredMembers() groups = %v, expected %v", actual, expected)
+	}
+
+	if groups[0].Annotations[constants.SyncMemberExpiry] != `{"jim":"2026-10-03T12:00:00Z"}` {
+		t.Errorf("RemoveExpiredMembers() expiry annotation = '%s'", groups[0].Annotations[constants.SyncMemberExpiry])
+	}
+
+	if _, found := groups[2].Annotations[constants.SyncMemberExpiry]; found {
+		t.Errorf("RemoveExpiredMembers() retained the expiry annotation of a group without upcoming expirations")
+	}
+}
@@ -0,0 +1,149 @@
+package syncer
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/google/cel-go/cel"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// expression extracts values from decoded JSON documents
+type expression interface {
+	// evaluate returns the values selected by the expression. Lists are flattened into the returned values
+	evaluate(data interface{}) ([]interface{}, error)
+}
+
+// jsonPathExpression is an expression using the JSONPath syntax supported by kubectl
+type jsonPathExpression struct {
+	parser *jsonpath.JSONPath
+}
+
+// celExpression is an expression using the Common Expression Language
+type celExpression struct {
+	program  cel.Program
+	variable string
+}
+
+// compileExpression compiles an expression in the given language. CEL expressions reference the document using the provided variable name
+func compileExpression(language redhatcopv1beta1.ExpressionLanguage, variable string, source string) (expression, error) {
+
+	switch language {
+	case "", redhatcopv1beta1.JSONPathExpressionLanguage:
+		parser := jsonpath.New(variable).AllowMissingKeys(true)
+		if err := parser.Parse(source); err != nil {
+			return nil, fmt.Errorf("Invalid JSONPath expression '%s': %w", source, err)
+		}
+		return &jsonPathExpression{parser: parser}, nil
+	case redhatcopv1beta1.CELExpressionLanguage:
+		env, err := cel.NewEnv(cel.Variable(variable, cel.DynType))
+		if err != nil {
+			return nil, err
+		}
+
+		ast, issues := env.Compile(source)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("Invalid CEL expression '%s': %w", source, issues.Err())
+		}
+
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, err
+		}
+		return &celExpression{program: program, variable: variable}, nil
+	}
+
+	return nil, fmt.Errorf("Unsupported expression language '%s'", language)
+}
+
+func (j *jsonPathExpression) evaluate(data interface{}) ([]interface{}, error) {
+
+	results, err := j.parser.FindResults(data)
+	if err != nil {
+		return nil, err
+	}
+
+	values := []interface{}{}
+	for _, result := range results {
+		for _, value := range result {
+			if value.Kind() == reflect.Interface && value.IsNil() {
+				continue
+			}
+			values = append(values, flattenValue(value.Interface())...)
+		}
+	}
+
+	return values, nil
+}
+
+func (c *celExpression) evaluate(data interface{}) ([]interface{}, error) {
+
+	out, _, err := c.program.Eval(map[string]interface{}{c.variable: data})
+	if err != nil {
+		return nil, err
+	}
+
+	native, err := out.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+	if err != nil {
+		return nil, err
+	}
+
+	value := native.(*structpb.Value).AsInterface()
+	if value == nil {
+		return []interface{}{}, nil
+	}
+
+	return flattenValue(value), nil
+}
+
+// flattenValue returns the elements of a list or the value itself
+func flattenValue(value interface{}) []interface{} {
+	if values, ok := value.([]interface{}); ok {
+		return values
+	}
+
+	return []interface{}{value}
+}
+
+// evaluateString returns the first value selected by an expression as a string
+func evaluateString(expression expression, data interface{}) (string, error) {
+
+	values, err := expression.evaluate(data)
+	if err != nil || len(values) == 0 {
+		return "", err
+	}
+
+	return stringifyValue(values[0]), nil
+}
+
+// evaluateStrings returns all values selected by an expression as strings
+func evaluateStrings(expression expression, data interface{}) ([]string, error) {
+
+	values, err := expression.evaluate(data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []string{}
+	for _, value := range values {
+		if value != nil {
+			result = append(result, stringifyValue(value))
+		}
+	}
+
+	return result, nil
+}
+
+func stringifyValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,186 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	userv1 "github.com/openshift/api/user/v1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"github.com/redhat-cop/group-sync-operator/pkg/provider/external"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	externalLogger         = logf.Log.WithName("syncer_external")
+	defaultExternalTimeout = time.Minute
+)
+
+// ExternalPluginDirectory is the directory containing the plugins that external providers are permitted to execute
+var ExternalPluginDirectory = ""
+
+type ExternalSyncer struct {
+	Name              string
+	GroupSync         *redhatcopv1beta1.GroupSync
+	Provider          *redhatcopv1beta1.ExternalProvider
+	Context           context.Context
+	ReconcilerBase    util.ReconcilerBase
+	PluginClient      external.PluginClient
+	CredentialsSecret *corev1.Secret
+	CaCertificate     []byte
+	groupFilter       *groupNameFilter
+}
+
+func (e *ExternalSyncer) Init() bool {
+	e.Context = context.Background()
+	return false
+}
+
+func (e *ExternalSyncer) Validate() error {
+
+	validationErrors := []error{}
+
+	if ExternalPluginDirectory == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("External providers are disabled as no plugin directory has been configured"))
+	}
+
+	if e.Provider.Plugin == "" || filepath.Base(e.Provider.Plugin) != e.Provider.Plugin || e.Provider.Plugin == "." || e.Provider.Plugin == ".." {
+		validationErrors = append(validationErrors, fmt.Errorf("Invalid plugin name: '%s'", e.Provider.Plugin))
+	}
+
+	if e.Provider.Timeout != nil && e.Provider.Timeout.Duration <= 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("Timeout must be greater than zero"))
+	}
+
+	if e.Provider.CredentialsSecret != nil {
+		credentialsSecret, err := getCredentialsSecret(e.Context, e.ReconcilerBase.GetClient(), e.GroupSync, e.Provider.CredentialsSecret)
+
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		} else {
+			e.CredentialsSecret = credentialsSecret
+		}
+	}
+
+	if e.Provider.Ca != nil {
+		caCertificate, err := getCaCertificate(e.Context, e.ReconcilerBase.GetClient(), e.GroupSync, e.Provider.Ca)
+
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+
+		e.CaCertificate = caCertificate
+	}
+
+	groupFilter, err := newGroupNameFilter(e.Provider.Groups, e.Provider.GroupPatterns)
+	if err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+	e.groupFilter = groupFilter
+
+	return utilerrors.NewAggregate(validationErrors)
+}
+
+func (e *ExternalSyncer) Bind() error {
+
+	timeout := defaultExternalTimeout
+	if e.Provider.Timeout != nil {
+		timeout = e.Provider.Timeout.Duration
+	}
+
+	e.PluginClient = &external.ExecClient{
+		Path:    filepath.Join(ExternalPluginDirectory, e.Provider.Plugin),
+		Args:    e.Provider.Args,
+		Timeout: timeout,
+	}
+
+	return nil
+}
+
+func (e *ExternalSyncer) Sync() ([]userv1.Group, error) {
+
+	request := &external.Request{
+		ProtocolVersion: external.ProtocolVersion,
+		ProviderName:    e.Name,
+		Config:          e.Provider.Config,
+		CaCertificate:   string(e.CaCertificate),
+		Insecure:        e.Provider.Insecure,
+	}
+
+	// Plugins only return the listed groups, so every group is requested and filtered locally when include patterns are provided
+	if e.Provider.GroupPatterns == nil || len(e.Provider.GroupPatterns.Include) == 0 {
+		request.Groups = e.Provider.Groups
+	}
+
+	if e.CredentialsSecret != nil {
+		request.Credentials = map[string]string{}
+		for key, value := range e.CredentialsSecret.Data {
+			request.Credentials[key] = string(value)
+		}
+	}
+
+	response, err := e.PluginClient.GetGroups(e.Context, request)
+	if err != nil {
+		return nil, err
+	}
+
+	ocpGroups := []userv1.Group{}
+
+	for _, group := range response.Groups {
+
+		if group.Name == "" {
+			return nil, fmt.Errorf("Plugin '%s' returned a group without a name", e.Provider.Plugin)
+		}
+
+		if !e.groupFilter.allows(group.Name) {
+			continue
+		}
+
+		ocpGroup := userv1.Group{
+			TypeMeta: v1.TypeMeta{
+				Kind:       "Group",
+				APIVersion: userv1.GroupVersion.String(),
+			},
+			ObjectMeta: v1.ObjectMeta{
+				Name:        group.Name,
+				Annotations: map[string]string{},
+				Labels:      map[string]string{},
+			},
+			Users: []string{},
+		}
+
+		for key, value := range group.Annotations {
+			ocpGroup.Annotations[key] = value
+		}
+
+		for key, value := range group.Labels {
+			ocpGroup.Labels[key] = value
+		}
+
+		if group.UID != "" {
+			ocpGroup.Annotations[constants.SyncSourceUID] = group.UID
+		}
+
+		ocpGroup.Users = append(ocpGroup.Users, group.Members...)
+
+		ocpGroups = append(ocpGroups, ocpGroup)
+	}
+
+	externalLogger.Info("Retrieved groups from plugin", "Provider", e.Name, "Plugin", e.Provider.Plugin, "Groups", len(ocpGroups))
+
+	return ocpGroups, nil
+}
+
+func (e *ExternalSyncer) GetProviderName() string {
+	return e.Name
+}
+
+func (e *ExternalSyncer) GetPrune() bool {
+	return e.Provider.Prune
+}
@@ -0,0 +1,88 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	"github.com/redhat-cop/group-sync-operator/pkg/provider/external"
+)
+
+type testPluginClient struct {
+	request *external.Request
+}
+
+func (c *testPluginClient) GetGroups(ctx context.Context, request *external.Request) (*external.Response, error) {
+	c.request = request
+
+	response := &external.Response{}
+	for _, name := range []string{"admins", "developers", "ops-team", "sre-team"} {
+		if len(request.Groups) > 0 && !slices.Contains(request.Groups, name) {
+			continue
+		}
+		response.Groups = append(response.Groups, external.Group{Name: name})
+	}
+
+	return response, nil
+}
+
+func TestExternalSyncGroupPatterns(t *testing.T) {
+	tests := []struct {
+		name              string
+		provider          redhatcopv1beta1.ExternalProvider
+		expectedRequested []string
+		expectedGroups    []string
+	}{
+		{
+			name:              "names",
+			provider:          redhatcopv1beta1.ExternalProvider{GroupFilter: redhatcopv1beta1.GroupFilter{Groups: []string{"admins"}}},
+			expectedRequested: []string{"admins"},
+			expectedGroups:    []string{"admins"},
+		},
+		{
+			name:              "names and include patterns",
+			provider:          redhatcopv1beta1.ExternalProvider{GroupFilter: redhatcopv1beta1.GroupFilter{Groups: []string{"admins"}, GroupPatterns: &redhatcopv1beta1.PatternFilter{Include: []string{"*-team"}}}},
+			expectedRequested: []string{},
+			expectedGroups:    []string{"admins", "ops-team", "sre-team"},
+		},
+		{
+			name:              "names and exclude patterns",
+			provider:          redhatcopv1beta1.ExternalProvider{GroupFilter: redhatcopv1beta1.GroupFilter{Groups: []string{"admins", "ops-team"}, GroupPatterns: &redhatcopv1beta1.PatternFilter{Exclude: []string{"ops-*"}}}},
+			expectedRequested: []string{"admins", "ops-team"},
+			expectedGroups:    []string{"admins"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groupFilter, err := newGroupNameFilter(tt.provider.Groups, tt.provider.GroupPatterns)
+			if err != nil {
+				t.Fatalf("newGroupNameFilter() error = %v", err)
+			}
+
+			pluginClient := &testPluginClient{}
+			externalSyncer := &ExternalSyncer{Name: "external", Provider: &tt.provider, PluginClient: pluginClient, groupFilter: groupFilter}
+			externalSyncer.Init()
+
+			groups, err := externalSyncer.Sync()
+			if err != nil {
+				t.Fatalf("Sync() error = %v", err)
+			}
+
+			if fmt.Sprint(pluginClient.request.Groups) != fmt.Sprint(tt.expectedRequested) {
+				t.Errorf("Sync() requested groups = %v, expected %v", pluginClient.request.Groups, tt.expectedRequested)
+			}
+
+			actual := []string{}
+			for _, group := range groups {
+				actual = append(actual, group.Name)
+			}
+
+			if fmt.Sprint(actual) != fmt.Sprint(tt.expectedGroups) {
+				t.Errorf("Sync() = %v, expected %v", actual, tt.expectedGroups)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package syncer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// groupNameFilter selects groups by name using a list of names along with include and exclude patterns
+type groupNameFilter struct {
+	names   sets.Set[string]
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// newGroupNameFilter returns a filter selecting the provided names along with the names matching the patterns
+func newGroupNameFilter(names []string, patterns *redhatcopv1beta1.PatternFilter) (*groupNameFilter, error) {
+	filter := &groupNameFilter{names: sets.New(names...)}
+
+	if patterns == nil {
+		return filter, nil
+	}
+
+	var err error
+	if filter.include, err = compilePatterns(patterns.Include, patterns.Syntax); err != nil {
+		return nil, err
+	}
+	if filter.exclude, err = compilePatterns(patterns.Exclude, patterns.Syntax); err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
+
+// compilePatterns compiles glob or regular expression patterns into regular expressions matching entire names
+func compilePatterns(patterns []string, syntax redhatcopv1beta1.PatternSyntax) ([]*regexp.Regexp, error) {
+	expressions := []*regexp.Regexp{}
+
+	for _, pattern := range patterns {
+		expression := pattern
+		if syntax != redhatcopv1beta1.RegexPatternSyntax {
+			expression = globToRegex(pattern)
+		}
+
+		compiled, err := regexp.Compile("^(?:" + expression + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("Invalid group pattern '%s': %w", pattern, err)
+		}
+
+		expressions = append(expressions, compiled)
+	}
+
+	return expressions, nil
+}
+
+// globToRegex converts a glob pattern supporting the * and ? wildcards into a regular expression
+func globToRegex(pattern string) string {
+	var expression strings.Builder
+
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			expression.WriteString(".*")
+		case '?':
+			expression.WriteString(".")
+		default:
+			expression.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	return expression.String()
+}
+
+// selects returns whether any of the candidate names is listed or matches an include pattern. All names are selected
+// when neither names nor include patterns are provided
+func (f *groupNameFilter) selects(candidates ...string) bool {
+	if f.names.Len() == 0 && len(f.include) == 0 {
+		return true
+	}
+
+	for _, candidate := range candidates {
+		if f.names.Has(candidate) || matchesAny(f.include, candidate) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// excludes returns whether any of the candidate names matches an exclude pattern
+func (f *groupNameFilter) excludes(candidates ...string) bool {
+	for _, candidate := range candidates {
+		if matchesAny(f.exclude, candidate) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// allows returns whether a group known by any of the candidate names is selected and not excluded
+func (f *groupNameFilter) allows(candidates ...string) bool {
+	return f.selects(candidates...) && !f.excludes(candidates...)
+}
+
+func matchesAny(expressions []*regexp.Regexp, name string) bool {
+	for _, expression := range expressions {
+		if expression.MatchString(name) {
+			return true
+		}
+	}
+
+	return false
+}
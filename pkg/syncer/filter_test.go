@@ -0,0 +1,92 @@
+package syncer
+
+import (
+	"strings"
+	"testing"
+
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+)
+
+func TestGroupNameFilter(t *testing.T) {
+	groups := []string{"admins", "app-dev", "app-ops", "app.v2", "developers"}
+
+	tests := []struct {
+		name          string
+		names         []string
+		patterns      *redhatcopv1beta1.PatternFilter
+		expected      []string
+		expectedError string
+	}{
+		{
+			name:     "no filter",
+			expected: groups,
+		},
+		{
+			name:     "names",
+			names:    []string{"admins", "unknown"},
+			expected: []string{"admins"},
+		},
+		{
+			name:     "glob include",
+			patterns: &redhatcopv1beta1.PatternFilter{Include: []string{"app-*"}},
+			expected: []string{"app-dev", "app-ops"},
+		},
+		{
+			name:     "glob matches literal characters",
+			patterns: &redhatcopv1beta1.PatternFilter{Include: []string{"app.v?"}},
+			expected: []string{"app.v2"},
+		},
+		{
+			name:     "names and glob include",
+			names:    []string{"admins"},
+			patterns: &redhatcopv1beta1.PatternFilter{Include: []string{"*-ops"}},
+			expected: []string{"admins", "app-ops"},
+		},
+		{
+			name:     "glob exclude",
+			patterns: &redhatcopv1beta1.PatternFilter{Exclude: []string{"app*"}},
+			expected: []string{"admins", "developers"},
+		},
+		{
+			name:     "regex include and exclude",
+			patterns: &redhatcopv1beta1.PatternFilter{Include: []string{"app-[a-z]+", "dev.*"}, Exclude: []string{".*-ops"}, Syntax: redhatcopv1beta1.RegexPatternSyntax},
+			expected: []string{"app-dev", "developers"},
+		},
+		{
+			name:     "regex matches entire name",
+			patterns: &redhatcopv1beta1.PatternFilter{Include: []string{"dev"}, Syntax: redhatcopv1beta1.RegexPatternSyntax},
+			expected: []string{},
+		},
+		{
+			name:          "invalid regex",
+			patterns:      &redhatcopv1beta1.PatternFilter{Include: []string{"app-("}, Syntax: redhatcopv1beta1.RegexPatternSyntax},
+			expectedError: "Invalid group pattern 'app-('",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := newGroupNameFilter(tt.names, tt.patterns)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("newGroupNameFilter() error = %v, expected %s", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newGroupNameFilter() error = %v", err)
+			}
+
+			actual := []string{}
+			for _, group := range groups {
+				if filter.allows(group) {
+					actual = append(actual, group)
+				}
+			}
+
+			if strings.Join(actual, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("allows() = %v, expected %v", actual, tt.expected)
+			}
+		})
+	}
+}
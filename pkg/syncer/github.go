@@ -2,11 +2,9 @@ package syncer
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
 	"fmt"
-	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 
 	"github.com/gregjones/httpcache"
@@ -15,13 +13,13 @@ import (
 	"github.com/google/go-github/v45/github"
 	userv1 "github.com/openshift/api/user/v1"
 	"github.com/palantir/go-githubapp/githubapp"
-	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
 	"github.com/redhat-cop/group-sync-operator/pkg/constants"
 	"github.com/redhat-cop/operator-utils/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -59,16 +57,26 @@ const (
 )
 
 type GitHubSyncer struct {
-	Name              string
-	GroupSync         *redhatcopv1alpha1.GroupSync
-	Provider          *redhatcopv1alpha1.GitHubProvider
-	Client            *github.Client
-	V4Client          *githubv4.Client
-	Context           context.Context
-	ReconcilerBase    util.ReconcilerBase
-	CredentialsSecret *corev1.Secret
-	URL               *url.URL
-	CaCertificate     []byte
+	Name                string
+	GroupSync           *redhatcopv1beta1.GroupSync
+	Provider            *redhatcopv1beta1.GitHubProvider
+	Context             context.Context
+	ReconcilerBase      util.ReconcilerBase
+	CredentialsSecret   *corev1.Secret
+	URL                 *url.URL
+	httpClientConfig    *httpClientConfig
+	organizationClients []gitHubOrganizationClient
+	enterpriseClient    *github.Client
+	teamFilter          *groupNameFilter
+	repositoryFilter    *groupNameFilter
+}
+
+// gitHubOrganizationClient represents the clients used to access a single organization. When authenticating as a
+// GitHub App, each organization uses the token of the installation of the App in that organization
+type gitHubOrganizationClient struct {
+	organization string
+	client       *github.Client
+	v4Client     *githubv4.Client
 }
 
 func (g *GitHubSyncer) Init() bool {
@@ -84,8 +92,7 @@ func (g *GitHubSyncer) Validate() error {
 	validationErrors := []error{}
 
 	if g.Provider.CredentialsSecret != nil {
-		credentialsSecret := &corev1.Secret{}
-		err := g.ReconcilerBase.GetClient().Get(g.Context, types.NamespacedName{Name: g.Provider.CredentialsSecret.Name, Namespace: g.Provider.CredentialsSecret.Namespace}, credentialsSecret)
+		credentialsSecret, err := getCredentialsSecret(g.Context, g.ReconcilerBase.GetClient(), g.GroupSync, g.Provider.CredentialsSecret)
 
 		if err != nil {
 			validationErrors = append(validationErrors, err)
@@ -100,49 +107,60 @@ func (g *GitHubSyncer) Validate() error {
 			}
 
 			g.CredentialsSecret = credentialsSecret
+
+			if g.Provider.AllOrganizations && !(privateKeyFound && integrationIdFound) {
+				validationErrors = append(validationErrors, fmt.Errorf("allOrganizations requires authenticating as a GitHub App"))
+			}
+
+			if g.Provider.Enterprise != nil && !tokenSecretFound {
+				validationErrors = append(validationErrors, fmt.Errorf("enterprise requires authenticating using a token"))
+			}
 		}
 	} else {
 		validationErrors = append(validationErrors, fmt.Errorf("credentialsSecret must be provided for GitHub provider"))
 	}
 
-	if g.Provider.Organization == "" {
+	if g.Provider.Enterprise != nil {
+		validationErrors = append(validationErrors, g.validateEnterprise()...)
+	} else if g.Provider.AllOrganizations && len(g.getOrganizations()) > 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("allOrganizations cannot be combined with organization or organizations"))
+	} else if !g.Provider.AllOrganizations && len(g.getOrganizations()) == 0 {
 		validationErrors = append(validationErrors, fmt.Errorf("Organization name not provided"))
 	}
 
-	providerCaResource := determineFromDeprecatedObjectRef(g.Provider.Ca, g.Provider.CaSecret)
-	if providerCaResource != nil {
-
-		caResource, err := getObjectRefData(g.Context, g.ReconcilerBase.GetClient(), providerCaResource)
-
-		if err != nil {
-			validationErrors = append(validationErrors, err)
-		}
+	teamFilter, err := newGroupNameFilter(g.Provider.Teams, g.Provider.TeamPatterns)
+	if err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+	g.teamFilter = teamFilter
 
-		var resourceCaKey string
-		if providerCaResource.Key != "" {
-			resourceCaKey = providerCaResource.Key
-		} else {
-			resourceCaKey = defaultResourceCaKey
-		}
+	if g.Provider.Repositories != nil {
+		validationErrors = append(validationErrors, g.validateRepositories()...)
+	} else if g.Provider.Enterprise == nil && g.Provider.Scope == redhatcopv1beta1.OneSyncScope && g.Provider.API != redhatcopv1beta1.GraphQLGitHubAPI {
+		// The REST API always includes the members of nested teams
+		validationErrors = append(validationErrors, fmt.Errorf("The one scope requires the graphql API"))
+	}
 
-		// Certificate key validation
-		if _, found := caResource[resourceCaKey]; !found {
-			validationErrors = append(validationErrors, fmt.Errorf("Could not find '%s' key in %s '%s' in namespace '%s'", resourceCaKey, providerCaResource.Kind, providerCaResource.Name, providerCaResource.Namespace))
-		}
+	if g.Provider.MapByScimId && g.getUserMapping() != redhatcopv1beta1.ScimIdGitHubUserMapping {
+		validationErrors = append(validationErrors, fmt.Errorf("mapByScimId cannot be combined with userMapping '%s'", g.Provider.UserMapping))
+	}
 
-		g.CaCertificate = caResource[resourceCaKey]
+	httpClientConfig, err := newHTTPClientConfig(g.Context, g.ReconcilerBase.GetClient(), g.GroupSync, g.Provider.ProviderBase, g.Provider.HTTPClientOptions)
+	if err != nil {
+		validationErrors = append(validationErrors, err)
 	}
+	g.httpClientConfig = httpClientConfig
 
-	if g.Provider.URL != nil {
-		if (*g.Provider.URL)[len(*g.Provider.URL)-1] != '/' {
+	if g.Provider.URL != "" {
+		if g.Provider.URL[len(g.Provider.URL)-1] != '/' {
 			validationErrors = append(validationErrors, fmt.Errorf("GitHub URL Must end with a slash ('/')"))
 		}
 
 		var err error
-		g.URL, err = url.Parse(*g.Provider.URL)
+		g.URL, err = url.Parse(g.Provider.URL)
 
 		if err != nil {
-			validationErrors = append(validationErrors, fmt.Errorf("Invalid GitHub URL: '%s'", *g.Provider.URL))
+			validationErrors = append(validationErrors, fmt.Errorf("Invalid GitHub URL: '%s'", g.Provider.URL))
 		}
 	}
 
@@ -155,43 +173,24 @@ func (g *GitHubSyncer) Bind() error {
 	privateKey, privateKeyFound := g.CredentialsSecret.Data[privateKey]
 	appId, appIdFound := g.CredentialsSecret.Data[appId]
 
-	var ghClient *github.Client
-	var transport *http.Transport
-
-	if g.Provider.Insecure == true {
-		transport = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		}
-	} else {
-		if len(g.CaCertificate) > 0 {
-
-			tlsConfig := &tls.Config{}
-			if tlsConfig.RootCAs == nil {
-				tlsConfig.RootCAs = x509.NewCertPool()
-			}
-
-			tlsConfig.RootCAs.AppendCertsFromPEM(g.CaCertificate)
-
-			transport = &http.Transport{
-				TLSClientConfig: tlsConfig,
-			}
-		}
-	}
+	g.organizationClients = []gitHubOrganizationClient{}
 
 	config := githubapp.Config{
-		V3APIURL: *g.Provider.URL,
-		V4APIURL: *g.Provider.V4URL,
+		V3APIURL: g.Provider.URL,
+		V4APIURL: g.Provider.V4URL,
 	}
 
 	opts := []githubapp.ClientOption{
 		githubapp.WithClientUserAgent(userAgent),
 		githubapp.WithClientCaching(false, func() httpcache.Cache { return httpcache.NewMemoryCache() }),
+		githubapp.WithTransport(g.httpClientConfig.transport()),
 	}
-	if transport != nil {
-		opts = append(opts, githubapp.WithTransport(transport))
+	if g.httpClientConfig.timeout > 0 {
+		opts = append(opts, githubapp.WithClientTimeout(g.httpClientConfig.timeout))
 	}
 
-	if privateKeyFound && appIdFound {
+	// Enterprises can only be accessed using a token
+	if privateKeyFound && appIdFound && g.Provider.Enterprise == nil {
 		config.App.PrivateKey = string(privateKey)
 
 		intId, err := strconv.ParseInt(string(appId), 10, 64)
@@ -211,19 +210,35 @@ func (g *GitHubSyncer) Bind() error {
 		}
 
 		installService := githubapp.NewInstallationsService(appClient)
-		installation, err := installService.GetByOwner(g.Context, g.Provider.Organization)
-		if err != nil {
-			return err
-		}
 
-		ghClient, err = clientCreator.NewInstallationClient(installation.ID)
-		if err != nil {
-			return err
+		installations := []githubapp.Installation{}
+		if g.Provider.AllOrganizations {
+			installations, err = g.listOrganizationInstallations(appClient)
+			if err != nil {
+				return err
+			}
+		} else {
+			for _, organization := range g.getOrganizations() {
+				installation, err := installService.GetByOwner(g.Context, organization)
+				if err != nil {
+					return err
+				}
+				installations = append(installations, installation)
+			}
 		}
 
-		g.V4Client, err = clientCreator.NewInstallationV4Client(installation.ID)
-		if err != nil {
-			return err
+		for _, installation := range installations {
+			ghClient, err := clientCreator.NewInstallationClient(installation.ID)
+			if err != nil {
+				return err
+			}
+
+			v4Client, err := clientCreator.NewInstallationV4Client(installation.ID)
+			if err != nil {
+				return err
+			}
+
+			g.addOrganizationClient(installation.Owner, ghClient, v4Client)
 		}
 
 	} else if tokenSecretFound {
@@ -231,113 +246,313 @@ func (g *GitHubSyncer) Bind() error {
 		if err != nil {
 			return err
 		}
-		ghClient, err = clientCreator.NewTokenClient(string(tokenSecret))
+		ghClient, err := clientCreator.NewTokenClient(string(tokenSecret))
 		if err != nil {
 			return err
 		}
 
-		g.V4Client, err = clientCreator.NewTokenV4Client(string(tokenSecret))
+		if g.Provider.Enterprise != nil {
+			if g.URL != nil {
+				ghClient.BaseURL = g.URL
+			}
+			g.enterpriseClient = ghClient
+
+			return nil
+		}
+
+		v4Client, err := clientCreator.NewTokenV4Client(string(tokenSecret))
 		if err != nil {
 			return err
 		}
+
+		for _, organization := range g.getOrganizations() {
+			g.addOrganizationClient(organization, ghClient, v4Client)
+		}
 	} else {
 		return fmt.Errorf("Could not locate credentials in secret '%s' in namespace '%s'", g.Provider.CredentialsSecret.Name, g.Provider.CredentialsSecret.Namespace)
 	}
 
+	return nil
+}
+
+// listOrganizationInstallations returns the installations of the GitHub App in organizations, ignoring installations in user accounts
+func (g *GitHubSyncer) listOrganizationInstallations(appClient *github.Client) ([]githubapp.Installation, error) {
+	opts := &github.ListOptions{PerPage: pageSize}
+	installations := []githubapp.Installation{}
+
+	for {
+		appInstallations, r, err := appClient.Apps.ListInstallations(g.Context, opts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, installation := range appInstallations {
+			if installation.GetTargetType() == "Organization" {
+				installations = append(installations, githubapp.Installation{ID: installation.GetID(), Owner: installation.GetAccount().GetLogin(), OwnerID: installation.GetAccount().GetID()})
+			}
+		}
+
+		if r.NextPage == 0 {
+			break
+		}
+
+		opts.Page = r.NextPage
+	}
+
+	return installations, nil
+}
+
+// addOrganizationClient registers the clients used to access an organization
+func (g *GitHubSyncer) addOrganizationClient(organization string, ghClient *github.Client, v4Client *githubv4.Client) {
 	if g.URL != nil {
 		ghClient.BaseURL = g.URL
 	}
 
-	g.Client = ghClient
+	g.organizationClients = append(g.organizationClients, gitHubOrganizationClient{organization: organization, client: ghClient, v4Client: v4Client})
+}
 
-	return nil
+// getOrganizations returns the organizations explicitly configured for the provider
+func (g *GitHubSyncer) getOrganizations() []string {
+	organizations := []string{}
+
+	for _, organization := range append([]string{g.Provider.Organization}, g.Provider.Organizations...) {
+		if organization != "" && !slices.Contains(organizations, organization) {
+			organizations = append(organizations, organization)
+		}
+	}
+
+	return organizations
+}
+
+// isMultiOrganization returns whether teams from more than one organization may be synchronized, in which case group
+// names are prefixed with the organization to avoid collisions between teams of the same name
+func (g *GitHubSyncer) isMultiOrganization() bool {
+	return g.Provider.AllOrganizations || len(g.getOrganizations()) > 1
+}
+
+// getGroupName returns the name of the group for a team
+func (g *GitHubSyncer) getGroupName(organization string, teamName string) string {
+	if g.isMultiOrganization() {
+		return fmt.Sprintf("%s-%s", organization, teamName)
+	}
+
+	return teamName
 }
 
 func (g *GitHubSyncer) Sync() ([]userv1.Group, error) {
 
+	if g.Provider.Enterprise != nil {
+		return g.syncEnterprise()
+	}
+
 	ocpGroups := []userv1.Group{}
+	hierarchy := groupHierarchy{}
 
-	organization, _, err := g.Client.Organizations.Get(g.Context, g.Provider.Organization)
+	for _, organizationClient := range g.organizationClients {
+		var organizationGroups []userv1.Group
+		var err error
+
+		if g.Provider.Repositories != nil {
+			organizationGroups, err = g.syncOrganizationRepositories(organizationClient)
+		} else {
+			organizationGroups, err = g.syncOrganization(organizationClient, hierarchy)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		ocpGroups = append(ocpGroups, organizationGroups...)
+	}
+
+	// Members of nested teams are already included in their parents when using the sub scope
+	if err := hierarchy.apply(ocpGroups, g.Provider.Hierarchy); err != nil {
+		return nil, err
+	}
+
+	return ocpGroups, nil
+}
+
+// isTeamAllowed returns whether a team is selected by its privacy along with its name, slug or group name. When
+// synchronizing the sub scope, teams nested within a selected team are also allowed unless they are excluded
+func (g *GitHubSyncer) isTeamAllowed(organization string, team *github.Team, teamsByID map[int64]*github.Team) bool {
+	if len(g.Provider.TeamPrivacy) > 0 && !slices.Contains(g.Provider.TeamPrivacy, redhatcopv1beta1.GitHubTeamPrivacy(team.GetPrivacy())) {
+		return false
+	}
+
+	if g.teamFilter.excludes(g.getTeamNames(organization, team)...) {
+		return false
+	}
+
+	seenTeams := sets.New[int64]()
+
+	for current := team; current != nil && !seenTeams.Has(current.GetID()); current = teamsByID[current.GetParent().GetID()] {
+		seenTeams.Insert(current.GetID())
+
+		if g.teamFilter.selects(g.getTeamNames(organization, current)...) {
+			return true
+		}
+
+		if g.Provider.Scope != redhatcopv1beta1.SubSyncScope {
+			break
+		}
+	}
+
+	return false
+}
+
+// getTeamNames returns the names a team can be selected by
+func (g *GitHubSyncer) getTeamNames(organization string, team *github.Team) []string {
+	return []string{team.GetName(), team.GetSlug(), g.getGroupName(organization, team.GetName())}
+}
+
+// syncOrganization returns a group for each team in an organization, recording nested teams in the hierarchy
+func (g *GitHubSyncer) syncOrganization(organizationClient gitHubOrganizationClient, hierarchy groupHierarchy) ([]userv1.Group, error) {
+
+	ocpGroups := []userv1.Group{}
+
+	organization, _, err := organizationClient.client.Organizations.Get(g.Context, organizationClient.organization)
 
 	if err != nil {
-		gitHubLogger.Error(err, "Failed to get Organization", "Organization", g.Provider.Organization, "Provider", g.Name)
+		gitHubLogger.Error(err, "Failed to get Organization", "Organization", organizationClient.organization, "Provider", g.Name)
 		return nil, err
 	}
 
 	// Get List of Teams in Organization
-	teams, err := g.getOrganizationTeams()
+	var teams []*github.Team
+	var listTeamMembers gitHubTeamMemberLister
+
+	if g.Provider.API == redhatcopv1beta1.GraphQLGitHubAPI {
+		teams, listTeamMembers, err = g.getOrganizationTeamsGraphQL(organizationClient)
+	} else {
+		teams, err = g.getOrganizationTeams(organizationClient)
+		listTeamMembers = func(team *github.Team, role redhatcopv1beta1.GitHubTeamRole) ([]*github.User, error) {
+			return g.listTeamMembers(organizationClient, team.ID, organization.ID, role)
+		}
+	}
 
 	if err != nil {
-		gitHubLogger.Error(err, "Failed to get Teams", "Provider", g.Name)
+		gitHubLogger.Error(err, "Failed to get Teams", "Organization", organizationClient.organization, "Provider", g.Name)
 		return nil, err
 	}
 
-	var scimUserIdMap map[string]string = nil
-	if g.Provider.MapByScimId {
-		scimUserIdMap, err = g.getScimIdentity()
-		if err != nil {
-			return nil, err
-		}
+	userMap, err := g.getUserMap(organizationClient)
+	if err != nil {
+		gitHubLogger.Error(err, "Failed to get User Mapping", "Organization", organizationClient.organization, "Provider", g.Name)
+		return nil, err
 	}
 
+	teamsByID := map[int64]*github.Team{}
 	for _, team := range teams {
-		if !isGroupAllowed(*team.Name, g.Provider.Teams) {
+		teamsByID[team.GetID()] = team
+	}
+
+	for _, team := range teams {
+		groupName := g.getGroupName(organizationClient.organization, *team.Name)
+
+		if !g.isTeamAllowed(organizationClient.organization, team, teamsByID) {
 			continue
 		}
 
-		ocpGroup := userv1.Group{
-			TypeMeta: v1.TypeMeta{
-				Kind:       "Group",
-				APIVersion: userv1.GroupVersion.String(),
-			},
-			ObjectMeta: v1.ObjectMeta{
-				Name:        *team.Name,
-				Annotations: map[string]string{},
-				Labels:      map[string]string{},
-			},
-			Users: []string{},
+		if team.Parent != nil && team.Parent.Name != nil {
+			hierarchy.addChild(g.getGroupName(organizationClient.organization, *team.Parent.Name), groupName)
 		}
 
-		// Set Host Specific Details
-		ocpGroup.GetAnnotations()[constants.SyncSourceHost] = g.URL.Host
-		ocpGroup.GetAnnotations()[constants.SyncSourceUID] = strconv.FormatInt(*team.ID, 10)
+		teamMemberRole := g.Provider.TeamMemberRole
+		if teamMemberRole == "" {
+			teamMemberRole = redhatcopv1beta1.AllGitHubTeamRole
+		}
 
-		teamMembers, err := g.listTeamMembers(team.ID, organization.ID)
+		teamMembers, err := listTeamMembers(team, teamMemberRole)
 
 		if err != nil {
-			gitHubLogger.Error(err, "Failed to get Team Member for Team", "Team", team.Name, "Provider", g.Name)
+			gitHubLogger.Error(err, "Failed to get Team Member for Team", "Team", team.Name, "Organization", organizationClient.organization, "Provider", g.Name)
 			return nil, err
 		}
 
-		for _, teamMember := range teamMembers {
-			var userId string
-			if g.Provider.MapByScimId {
-				userId = scimUserIdMap[*teamMember.Login]
-			} else {
-				userId = *teamMember.Login
+		users, err := g.getUsers(teamMembers, userMap)
+		if err != nil {
+			return nil, fmt.Errorf("team '%s' in organization '%s': %w", *team.Name, organizationClient.organization, err)
+		}
+
+		ocpGroups = append(ocpGroups, g.newGroup(groupName, *team.ID, organizationClient.organization, users))
+
+		// Synchronize a Companion Group Containing the Maintainers of the Team
+		if g.Provider.MaintainerGroups {
+			if teamMemberRole != redhatcopv1beta1.MaintainerGitHubTeamRole {
+				teamMembers, err = listTeamMembers(team, redhatcopv1beta1.MaintainerGitHubTeamRole)
+
+				if err != nil {
+					gitHubLogger.Error(err, "Failed to get Team Maintainers for Team", "Team", team.Name, "Organization", organizationClient.organization, "Provider", g.Name)
+					return nil, err
+				}
+			}
+
+			users, err := g.getUsers(teamMembers, userMap)
+			if err != nil {
+				return nil, fmt.Errorf("team '%s' in organization '%s': %w", *team.Name, organizationClient.organization, err)
 			}
 
-			ocpGroup.Users = append(ocpGroup.Users, userId)
+			ocpGroups = append(ocpGroups, g.newGroup(fmt.Sprintf("%s-maintainers", groupName), *team.ID, organizationClient.organization, users))
 		}
+	}
+
+	// Synchronize a Group Containing the Owners of the Organization
+	if g.Provider.OwnersGroup != "" {
+		owners, err := g.listOrganizationOwners(organizationClient)
 
-		ocpGroups = append(ocpGroups, ocpGroup)
+		if err != nil {
+			gitHubLogger.Error(err, "Failed to get Organization Owners", "Organization", organizationClient.organization, "Provider", g.Name)
+			return nil, err
+		}
+
+		users, err := g.getUsers(owners, userMap)
+		if err != nil {
+			return nil, fmt.Errorf("owners of organization '%s': %w", organizationClient.organization, err)
+		}
+
+		ocpGroups = append(ocpGroups, g.newGroup(g.getGroupName(organizationClient.organization, g.Provider.OwnersGroup), *organization.ID, organizationClient.organization, users))
 	}
 
 	return ocpGroups, nil
 }
 
-func (g *GitHubSyncer) getScimIdentity() (map[string]string, error) {
+// newGroup returns a group sourced from the team or organization with the provided ID
+func (g *GitHubSyncer) newGroup(name string, sourceID int64, organization string, users []string) userv1.Group {
+	ocpGroup := userv1.Group{
+		TypeMeta: v1.TypeMeta{
+			Kind:       "Group",
+			APIVersion: userv1.GroupVersion.String(),
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{},
+			Labels:      map[string]string{},
+		},
+		Users: users,
+	}
+
+	// Set Host Specific Details
+	ocpGroup.GetAnnotations()[constants.SyncSourceHost] = g.URL.Host
+	ocpGroup.GetAnnotations()[constants.SyncSourceUID] = strconv.FormatInt(sourceID, 10)
+	ocpGroup.GetAnnotations()[constants.SyncSourceOrg] = organization
+
+	return ocpGroup
+}
+
+func (g *GitHubSyncer) getScimIdentity(organizationClient gitHubOrganizationClient) (map[string]string, error) {
 	const after = "after"
 	// query vars for graphQl
 	variables := map[string]interface{}{
-		"organization": githubv4.String(g.Provider.Organization),
+		"organization": githubv4.String(organizationClient.organization),
 		"first":        githubv4.Int(pageSize),
 		after:          (*githubv4.String)(nil),
 	}
 
 	userMap := make(map[string]string)
 	for { // while
-		err := g.V4Client.Query(g.Context, &scimQuery, variables)
+		err := organizationClient.v4Client.Query(g.Context, &scimQuery, variables)
 		if err != nil {
 			return nil, err
 		}
@@ -355,12 +570,12 @@ func (g *GitHubSyncer) getScimIdentity() (map[string]string, error) {
 	return userMap, nil
 }
 
-func (g *GitHubSyncer) getOrganizationTeams() ([]*github.Team, error) {
+func (g *GitHubSyncer) getOrganizationTeams(organizationClient gitHubOrganizationClient) ([]*github.Team, error) {
 	opts := &github.ListOptions{PerPage: pageSize}
 	var allTeams []*github.Team
 
 	for {
-		teams, r, err := g.Client.Teams.ListTeams(g.Context, g.Provider.Organization, opts)
+		teams, r, err := organizationClient.client.Teams.ListTeams(g.Context, organizationClient.organization, opts)
 
 		if err != nil {
 			return nil, err
@@ -380,16 +595,17 @@ func (g *GitHubSyncer) getOrganizationTeams() ([]*github.Team, error) {
 	return allTeams, nil
 }
 
-func (g *GitHubSyncer) listTeamMembers(teamID *int64, organizationID *int64) ([]*github.User, error) {
+func (g *GitHubSyncer) listTeamMembers(organizationClient gitHubOrganizationClient, teamID *int64, organizationID *int64, role redhatcopv1beta1.GitHubTeamRole) ([]*github.User, error) {
 
 	teamUsers := []*github.User{}
 
 	opts := github.TeamListTeamMembersOptions{
+		Role:        string(role),
 		ListOptions: github.ListOptions{PerPage: pageSize},
 	}
 
 	for {
-		users, resp, err := g.Client.Teams.ListTeamMembersByID(g.Context, *organizationID, *teamID, &opts)
+		users, resp, err := organizationClient.client.Teams.ListTeamMembersByID(g.Context, *organizationID, *teamID, &opts)
 		if err != nil {
 			return nil, err
 		}
@@ -404,6 +620,31 @@ func (g *GitHubSyncer) listTeamMembers(teamID *int64, organizationID *int64) ([]
 
 }
 
+// listOrganizationOwners returns the members of an organization with the admin role
+func (g *GitHubSyncer) listOrganizationOwners(organizationClient gitHubOrganizationClient) ([]*github.User, error) {
+
+	owners := []*github.User{}
+
+	opts := github.ListMembersOptions{
+		Role:        "admin",
+		ListOptions: github.ListOptions{PerPage: pageSize},
+	}
+
+	for {
+		users, resp, err := organizationClient.client.Organizations.ListMembers(g.Context, organizationClient.organization, &opts)
+		if err != nil {
+			return nil, err
+		}
+		owners = append(owners, users...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return owners, nil
+}
+
 func (g *GitHubSyncer) GetProviderName() string {
 	return g.Name
 }
@@ -0,0 +1,236 @@
+package syncer
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/go-github/v45/github"
+	userv1 "github.com/openshift/api/user/v1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const scimMediaType = "application/scim+json"
+
+// gitHubSCIMListResponse represents a page of resources returned by the enterprise SCIM API
+type gitHubSCIMListResponse[T any] struct {
+	TotalResults int `json:"totalResults"`
+	StartIndex   int `json:"startIndex"`
+	Resources    []T `json:"Resources"`
+}
+
+// gitHubSCIMGroup represents a group provisioned using the enterprise SCIM API
+type gitHubSCIMGroup struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	Members     []struct {
+		Value string `json:"value"`
+	} `json:"members"`
+}
+
+// gitHubSCIMUser represents a user provisioned using the enterprise SCIM API
+type gitHubSCIMUser struct {
+	ID       string `json:"id"`
+	UserName string `json:"userName"`
+}
+
+// gitHubEnterpriseTeam represents a team defined at the level of an enterprise
+type gitHubEnterpriseTeam struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// validateEnterprise verifies that the options of the provider can be used to synchronize an enterprise
+func (g *GitHubSyncer) validateEnterprise() []error {
+	validationErrors := []error{}
+
+	if g.Provider.Enterprise.Slug == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("Enterprise slug not provided"))
+	}
+
+	if len(g.getOrganizations()) > 0 || g.Provider.AllOrganizations {
+		validationErrors = append(validationErrors, fmt.Errorf("enterprise cannot be combined with organization, organizations or allOrganizations"))
+	}
+
+	if userMapping := g.getUserMapping(); userMapping == redhatcopv1beta1.ScimIdGitHubUserMapping || userMapping == redhatcopv1beta1.VerifiedEmailGitHubUserMapping {
+		validationErrors = append(validationErrors, fmt.Errorf("enterprise cannot be combined with userMapping '%s'", userMapping))
+	}
+
+	return validationErrors
+}
+
+// syncEnterprise returns a group for each SCIM group or team of the enterprise
+func (g *GitHubSyncer) syncEnterprise() ([]userv1.Group, error) {
+	if g.Provider.Enterprise.Source == redhatcopv1beta1.TeamsGitHubEnterpriseSource {
+		return g.syncEnterpriseTeams()
+	}
+
+	return g.syncEnterpriseSCIMGroups()
+}
+
+// syncEnterpriseSCIMGroups returns a group for each group provisioned using the enterprise SCIM API. Members are
+// synchronized using their SCIM user name
+func (g *GitHubSyncer) syncEnterpriseSCIMGroups() ([]userv1.Group, error) {
+	slug := g.Provider.Enterprise.Slug
+
+	scimUsers, err := listSCIMResources[gitHubSCIMUser](g, fmt.Sprintf("scim/v2/enterprises/%s/Users", slug))
+	if err != nil {
+		gitHubLogger.Error(err, "Failed to get SCIM Users", "Enterprise", slug, "Provider", g.Name)
+		return nil, err
+	}
+
+	userNames := map[string]string{}
+	for _, scimUser := range scimUsers {
+		userNames[scimUser.ID] = scimUser.UserName
+	}
+
+	scimGroups, err := listSCIMResources[gitHubSCIMGroup](g, fmt.Sprintf("scim/v2/enterprises/%s/Groups", slug))
+	if err != nil {
+		gitHubLogger.Error(err, "Failed to get SCIM Groups", "Enterprise", slug, "Provider", g.Name)
+		return nil, err
+	}
+
+	ocpGroups := []userv1.Group{}
+
+	for _, scimGroup := range scimGroups {
+		if !g.teamFilter.allows(scimGroup.DisplayName) {
+			continue
+		}
+
+		users := []string{}
+		for _, member := range scimGroup.Members {
+			userName, found := userNames[member.Value]
+
+			if !found {
+				switch g.Provider.UnmappedUserPolicy {
+				case redhatcopv1beta1.FailUnmappedUserPolicy:
+					return nil, fmt.Errorf("Unable to locate SCIM user '%s' of group '%s'", member.Value, scimGroup.DisplayName)
+				default:
+					gitHubLogger.Info("Skipping Unknown SCIM User", "User", member.Value, "Group", scimGroup.DisplayName, "Provider", g.Name)
+					continue
+				}
+			}
+
+			users = append(users, userName)
+		}
+
+		ocpGroups = append(ocpGroups, g.newEnterpriseGroup(scimGroup.DisplayName, scimGroup.ID, users))
+	}
+
+	return ocpGroups, nil
+}
+
+// syncEnterpriseTeams returns a group for each team of the enterprise
+func (g *GitHubSyncer) syncEnterpriseTeams() ([]userv1.Group, error) {
+	slug := g.Provider.Enterprise.Slug
+
+	teams, err := listEnterpriseResources[gitHubEnterpriseTeam](g, fmt.Sprintf("enterprises/%s/teams", slug))
+	if err != nil {
+		gitHubLogger.Error(err, "Failed to get Enterprise Teams", "Enterprise", slug, "Provider", g.Name)
+		return nil, err
+	}
+
+	ocpGroups := []userv1.Group{}
+
+	for _, team := range teams {
+		if !g.teamFilter.allows(team.Name, team.Slug) {
+			continue
+		}
+
+		members, err := listEnterpriseResources[*github.User](g, fmt.Sprintf("enterprises/%s/teams/%s/memberships", slug, team.Slug))
+		if err != nil {
+			gitHubLogger.Error(err, "Failed to get Team Member for Enterprise Team", "Team", team.Name, "Enterprise", slug, "Provider", g.Name)
+			return nil, err
+		}
+
+		users, err := g.getUsers(members, nil)
+		if err != nil {
+			return nil, fmt.Errorf("team '%s' in enterprise '%s': %w", team.Name, slug, err)
+		}
+
+		ocpGroups = append(ocpGroups, g.newEnterpriseGroup(team.Name, strconv.FormatInt(team.ID, 10), users))
+	}
+
+	return ocpGroups, nil
+}
+
+// newEnterpriseGroup returns a group sourced from the SCIM group or team of the enterprise with the provided ID
+func (g *GitHubSyncer) newEnterpriseGroup(name string, sourceID string, users []string) userv1.Group {
+	ocpGroup := userv1.Group{
+		TypeMeta: v1.TypeMeta{
+			Kind:       "Group",
+			APIVersion: userv1.GroupVersion.String(),
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{},
+			Labels:      map[string]string{},
+		},
+		Users: users,
+	}
+
+	// Set Host Specific Details
+	ocpGroup.GetAnnotations()[constants.SyncSourceHost] = g.URL.Host
+	ocpGroup.GetAnnotations()[constants.SyncSourceUID] = sourceID
+
+	return ocpGroup
+}
+
+// listSCIMResources returns every resource of an enterprise SCIM API endpoint
+func listSCIMResources[T any](g *GitHubSyncer, path string) ([]T, error) {
+	resources := []T{}
+
+	for startIndex := 1; ; {
+		req, err := g.enterpriseClient.NewRequest(http.MethodGet, fmt.Sprintf("%s?startIndex=%d&count=%d", path, startIndex, pageSize), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", scimMediaType)
+
+		var page gitHubSCIMListResponse[T]
+		if _, err := g.enterpriseClient.Do(g.Context, req, &page); err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, page.Resources...)
+
+		if len(page.Resources) == 0 || len(resources) >= page.TotalResults {
+			break
+		}
+
+		startIndex += len(page.Resources)
+	}
+
+	return resources, nil
+}
+
+// listEnterpriseResources returns every resource of a paginated enterprise REST API endpoint
+func listEnterpriseResources[T any](g *GitHubSyncer, path string) ([]T, error) {
+	resources := []T{}
+
+	for page := 1; ; {
+		req, err := g.enterpriseClient.NewRequest(http.MethodGet, fmt.Sprintf("%s?per_page=%d&page=%d", path, pageSize, page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var pageResources []T
+		resp, err := g.enterpriseClient.Do(g.Context, req, &pageResources)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, pageResources...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		page = resp.NextPage
+	}
+
+	return resources, nil
+}
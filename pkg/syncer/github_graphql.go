@@ -0,0 +1,203 @@
+package syncer
+
+import (
+	"github.com/google/go-github/v45/github"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	"github.com/shurcooL/githubv4"
+)
+
+// gitHubTeamMemberLister returns the members of a team with the provided role
+type gitHubTeamMemberLister func(team *github.Team, role redhatcopv1beta1.GitHubTeamRole) ([]*github.User, error)
+
+type gitHubPageInfo struct {
+	HasNextPage githubv4.Boolean
+	EndCursor   githubv4.String
+}
+
+type gitHubTeamMemberConnection struct {
+	PageInfo gitHubPageInfo
+	Edges    []struct {
+		Role githubv4.TeamMemberRole
+		Node struct {
+			Login githubv4.String
+		}
+	}
+}
+
+type gitHubTeamNode struct {
+	DatabaseId githubv4.Int
+	Name       githubv4.String
+	Slug       githubv4.String
+	Privacy    githubv4.TeamPrivacy
+	ParentTeam *struct {
+		DatabaseId githubv4.Int
+		Name       githubv4.String
+		Slug       githubv4.String
+	}
+	Members gitHubTeamMemberConnection `graphql:"members(first: $first, membership: $membership)"`
+}
+
+// gitHubTeamsQuery retrieves a page of the teams of an organization along with the first page of members of each team
+type gitHubTeamsQuery struct {
+	Organization struct {
+		Teams struct {
+			PageInfo gitHubPageInfo
+			Nodes    []gitHubTeamNode
+		} `graphql:"teams(first: $first, after: $after)"`
+	} `graphql:"organization(login: $organization)"`
+}
+
+// gitHubTeamMembersQuery retrieves a subsequent page of the members of a team
+type gitHubTeamMembersQuery struct {
+	Organization struct {
+		Team struct {
+			Members gitHubTeamMemberConnection `graphql:"members(first: $first, after: $after, membership: $membership)"`
+		} `graphql:"team(slug: $slug)"`
+	} `graphql:"organization(login: $organization)"`
+}
+
+// gitHubTeamMember represents a member of a team along with their role within the team
+type gitHubTeamMember struct {
+	login string
+	role  githubv4.TeamMemberRole
+}
+
+// getOrganizationTeamsGraphQL retrieves the teams of an organization along with their members using the GraphQL API.
+// Teams are retrieved in pages containing the first page of members of each team, so additional queries are only
+// required for teams with more members than fit in a single page
+func (g *GitHubSyncer) getOrganizationTeamsGraphQL(organizationClient gitHubOrganizationClient) ([]*github.Team, gitHubTeamMemberLister, error) {
+	variables := map[string]interface{}{
+		"organization": githubv4.String(organizationClient.organization),
+		"first":        githubv4.Int(pageSize),
+		"after":        (*githubv4.String)(nil),
+		"membership":   g.getTeamMembershipType(),
+	}
+
+	teams := []*github.Team{}
+	teamMembers := map[int64][]gitHubTeamMember{}
+
+	for {
+		var query gitHubTeamsQuery
+		if err := organizationClient.v4Client.Query(g.Context, &query, variables); err != nil {
+			return nil, nil, err
+		}
+
+		for _, node := range query.Organization.Teams.Nodes {
+			team := &github.Team{
+				ID:      github.Int64(int64(node.DatabaseId)),
+				Name:    github.String(string(node.Name)),
+				Slug:    github.String(string(node.Slug)),
+				Privacy: github.String(toGitHubTeamPrivacy(node.Privacy)),
+			}
+
+			if node.ParentTeam != nil {
+				team.Parent = &github.Team{
+					ID:   github.Int64(int64(node.ParentTeam.DatabaseId)),
+					Name: github.String(string(node.ParentTeam.Name)),
+					Slug: github.String(string(node.ParentTeam.Slug)),
+				}
+			}
+
+			members := toGitHubTeamMembers(node.Members)
+
+			if node.Members.PageInfo.HasNextPage {
+				remainingMembers, err := g.getTeamMembersGraphQL(organizationClient, string(node.Slug), githubv4.NewString(node.Members.PageInfo.EndCursor))
+				if err != nil {
+					return nil, nil, err
+				}
+				members = append(members, remainingMembers...)
+			}
+
+			teams = append(teams, team)
+			teamMembers[team.GetID()] = members
+		}
+
+		if !query.Organization.Teams.PageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = githubv4.NewString(query.Organization.Teams.PageInfo.EndCursor)
+	}
+
+	listTeamMembers := func(team *github.Team, role redhatcopv1beta1.GitHubTeamRole) ([]*github.User, error) {
+		return toGitHubUsers(teamMembers[team.GetID()], role), nil
+	}
+
+	return teams, listTeamMembers, nil
+}
+
+// getTeamMembersGraphQL retrieves the members of a team following the provided cursor, starting from the first member
+// when no cursor is provided
+func (g *GitHubSyncer) getTeamMembersGraphQL(organizationClient gitHubOrganizationClient, slug string, after *githubv4.String) ([]gitHubTeamMember, error) {
+	variables := map[string]interface{}{
+		"organization": githubv4.String(organizationClient.organization),
+		"slug":         githubv4.String(slug),
+		"first":        githubv4.Int(pageSize),
+		"after":        after,
+		"membership":   g.getTeamMembershipType(),
+	}
+
+	members := []gitHubTeamMember{}
+
+	for {
+		var query gitHubTeamMembersQuery
+		if err := organizationClient.v4Client.Query(g.Context, &query, variables); err != nil {
+			return nil, err
+		}
+
+		members = append(members, toGitHubTeamMembers(query.Organization.Team.Members)...)
+
+		if !query.Organization.Team.Members.PageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = githubv4.NewString(query.Organization.Team.Members.PageInfo.EndCursor)
+	}
+
+	return members, nil
+}
+
+// getTeamMembershipType returns the members of a team that are retrieved. Members of nested teams are included unless
+// synchronizing the one scope, matching the members returned by the REST API
+func (g *GitHubSyncer) getTeamMembershipType() githubv4.TeamMembershipType {
+	if g.Provider.Scope == redhatcopv1beta1.OneSyncScope {
+		return githubv4.TeamMembershipTypeImmediate
+	}
+
+	return githubv4.TeamMembershipTypeAll
+}
+
+// toGitHubTeamPrivacy converts the privacy of a team reported by the GraphQL API to the value reported by the REST API
+func toGitHubTeamPrivacy(privacy githubv4.TeamPrivacy) string {
+	if privacy == githubv4.TeamPrivacySecret {
+		return string(redhatcopv1beta1.SecretGitHubTeamPrivacy)
+	}
+
+	return string(redhatcopv1beta1.ClosedGitHubTeamPrivacy)
+}
+
+// toGitHubUsers returns the members of a team with the provided role
+func toGitHubUsers(members []gitHubTeamMember, role redhatcopv1beta1.GitHubTeamRole) []*github.User {
+	users := []*github.User{}
+
+	for _, member := range members {
+		if role == redhatcopv1beta1.MemberGitHubTeamRole && member.role != githubv4.TeamMemberRoleMember {
+			continue
+		}
+		if role == redhatcopv1beta1.MaintainerGitHubTeamRole && member.role != githubv4.TeamMemberRoleMaintainer {
+			continue
+		}
+
+		users = append(users, &github.User{Login: github.String(member.login)})
+	}
+
+	return users
+}
+
+func toGitHubTeamMembers(connection gitHubTeamMemberConnection) []gitHubTeamMember {
+	members := []gitHubTeamMember{}
+
+	for _, edge := range connection.Edges {
+		members = append(members, gitHubTeamMember{login: string(edge.Node.Login), role: edge.Role})
+	}
+
+	return members
+}
@@ -0,0 +1,270 @@
+package syncer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v45/github"
+	userv1 "github.com/openshift/api/user/v1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+)
+
+const (
+	defaultRepositoryNameTemplate = "{repository}-{permission}"
+	organizationPlaceholder       = "{organization}"
+	repositoryPlaceholder         = "{repository}"
+	permissionPlaceholder         = "{permission}"
+)
+
+// gitHubRepositoryPermissions are the permission levels of a repository ordered from least to most privileged
+var gitHubRepositoryPermissions = []redhatcopv1beta1.GitHubRepositoryPermission{
+	redhatcopv1beta1.ReadGitHubRepositoryPermission,
+	redhatcopv1beta1.TriageGitHubRepositoryPermission,
+	redhatcopv1beta1.WriteGitHubRepositoryPermission,
+	redhatcopv1beta1.MaintainGitHubRepositoryPermission,
+	redhatcopv1beta1.AdminGitHubRepositoryPermission,
+}
+
+// gitHubRepositoryPermissionKeys are the keys reported by the REST API for each permission level
+var gitHubRepositoryPermissionKeys = map[string]redhatcopv1beta1.GitHubRepositoryPermission{
+	"pull":     redhatcopv1beta1.ReadGitHubRepositoryPermission,
+	"triage":   redhatcopv1beta1.TriageGitHubRepositoryPermission,
+	"push":     redhatcopv1beta1.WriteGitHubRepositoryPermission,
+	"maintain": redhatcopv1beta1.MaintainGitHubRepositoryPermission,
+	"admin":    redhatcopv1beta1.AdminGitHubRepositoryPermission,
+}
+
+// validateRepositories verifies the options used to synchronize the collaborators of repositories
+func (g *GitHubSyncer) validateRepositories() []error {
+	validationErrors := []error{}
+
+	if g.Provider.Enterprise != nil {
+		validationErrors = append(validationErrors, fmt.Errorf("repositories cannot be combined with enterprise"))
+	}
+
+	repositoryFilter, err := newGroupNameFilter(g.Provider.Repositories.Names, g.Provider.Repositories.Patterns)
+	if err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+	g.repositoryFilter = repositoryFilter
+
+	nameTemplate := g.getRepositoryNameTemplate()
+
+	if !strings.Contains(nameTemplate, repositoryPlaceholder) && (len(g.Provider.Repositories.Names) != 1 || g.Provider.Repositories.Patterns != nil) {
+		validationErrors = append(validationErrors, fmt.Errorf("nameTemplate must contain %s unless a single repository is synchronized", repositoryPlaceholder))
+	}
+
+	if !strings.Contains(nameTemplate, permissionPlaceholder) && len(g.getRepositoryPermissions()) != 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("nameTemplate must contain %s unless a single permission is synchronized", permissionPlaceholder))
+	}
+
+	return validationErrors
+}
+
+// getRepositoryNameTemplate returns the template used to name the group of each repository and permission level
+func (g *GitHubSyncer) getRepositoryNameTemplate() string {
+	if g.Provider.Repositories.NameTemplate != "" {
+		return g.Provider.Repositories.NameTemplate
+	}
+
+	return defaultRepositoryNameTemplate
+}
+
+// getRepositoryPermissions returns the permission levels a group is synchronized for
+func (g *GitHubSyncer) getRepositoryPermissions() []redhatcopv1beta1.GitHubRepositoryPermission {
+	if len(g.Provider.Repositories.Permissions) > 0 {
+		return g.Provider.Repositories.Permissions
+	}
+
+	return gitHubRepositoryPermissions
+}
+
+// getRepositoryGroupName returns the name of the group for a permission level of a repository. Names are prefixed with
+// the organization when synchronizing multiple organizations unless the template references the organization
+func (g *GitHubSyncer) getRepositoryGroupName(organization string, repository string, permission redhatcopv1beta1.GitHubRepositoryPermission) string {
+	nameTemplate := g.getRepositoryNameTemplate()
+
+	name := strings.NewReplacer(
+		organizationPlaceholder, organization,
+		repositoryPlaceholder, repository,
+		permissionPlaceholder, string(permission),
+	).Replace(nameTemplate)
+
+	if strings.Contains(nameTemplate, organizationPlaceholder) {
+		return name
+	}
+
+	return g.getGroupName(organization, name)
+}
+
+// syncOrganizationRepositories returns a group for each permission level of the selected repositories of an
+// organization. Each group contains the direct collaborators and members of teams with at least the permission level
+func (g *GitHubSyncer) syncOrganizationRepositories(organizationClient gitHubOrganizationClient) ([]userv1.Group, error) {
+
+	organization, _, err := organizationClient.client.Organizations.Get(g.Context, organizationClient.organization)
+	if err != nil {
+		gitHubLogger.Error(err, "Failed to get Organization", "Organization", organizationClient.organization, "Provider", g.Name)
+		return nil, err
+	}
+
+	repositories, err := g.listOrganizationRepositories(organizationClient)
+	if err != nil {
+		gitHubLogger.Error(err, "Failed to get Repositories", "Organization", organizationClient.organization, "Provider", g.Name)
+		return nil, err
+	}
+
+	userMap, err := g.getUserMap(organizationClient)
+	if err != nil {
+		gitHubLogger.Error(err, "Failed to get User Mapping", "Organization", organizationClient.organization, "Provider", g.Name)
+		return nil, err
+	}
+
+	ocpGroups := []userv1.Group{}
+
+	for _, repository := range repositories {
+		if !g.repositoryFilter.allows(repository.GetName()) {
+			continue
+		}
+
+		collaborators, permissions, err := g.listRepositoryCollaborators(organizationClient, organization.GetID(), repository.GetName())
+		if err != nil {
+			gitHubLogger.Error(err, "Failed to get Collaborators for Repository", "Repository", repository.GetName(), "Organization", organizationClient.organization, "Provider", g.Name)
+			return nil, err
+		}
+
+		for _, permission := range g.getRepositoryPermissions() {
+			members := []*github.User{}
+			for _, collaborator := range collaborators {
+				if gitHubRepositoryPermissionLevel(permissions[collaborator.GetLogin()]) >= gitHubRepositoryPermissionLevel(permission) {
+					members = append(members, collaborator)
+				}
+			}
+
+			users, err := g.getUsers(members, userMap)
+			if err != nil {
+				return nil, fmt.Errorf("repository '%s' in organization '%s': %w", repository.GetName(), organizationClient.organization, err)
+			}
+
+			ocpGroup := g.newGroup(g.getRepositoryGroupName(organizationClient.organization, repository.GetName(), permission), repository.GetID(), organizationClient.organization, users)
+			ocpGroup.GetAnnotations()[constants.SyncSourceRepo] = repository.GetName()
+
+			ocpGroups = append(ocpGroups, ocpGroup)
+		}
+	}
+
+	return ocpGroups, nil
+}
+
+// listOrganizationRepositories returns the repositories of an organization
+func (g *GitHubSyncer) listOrganizationRepositories(organizationClient gitHubOrganizationClient) ([]*github.Repository, error) {
+	opts := &github.RepositoryListByOrgOptions{ListOptions: github.ListOptions{PerPage: pageSize}}
+	allRepositories := []*github.Repository{}
+
+	for {
+		repositories, resp, err := organizationClient.client.Repositories.ListByOrg(g.Context, organizationClient.organization, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		allRepositories = append(allRepositories, repositories...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return allRepositories, nil
+}
+
+// listRepositoryCollaborators returns the direct collaborators of a repository along with the members of the teams
+// granted access to the repository, and the highest permission level of each keyed by login
+func (g *GitHubSyncer) listRepositoryCollaborators(organizationClient gitHubOrganizationClient, organizationID int64, repository string) ([]*github.User, map[string]redhatcopv1beta1.GitHubRepositoryPermission, error) {
+	collaborators := []*github.User{}
+	permissions := map[string]redhatcopv1beta1.GitHubRepositoryPermission{}
+
+	addCollaborator := func(user *github.User, permission redhatcopv1beta1.GitHubRepositoryPermission) {
+		current, found := permissions[user.GetLogin()]
+		if !found {
+			collaborators = append(collaborators, user)
+		}
+		if !found || gitHubRepositoryPermissionLevel(permission) > gitHubRepositoryPermissionLevel(current) {
+			permissions[user.GetLogin()] = permission
+		}
+	}
+
+	// Direct Collaborators
+	opts := &github.ListCollaboratorsOptions{Affiliation: "direct", ListOptions: github.ListOptions{PerPage: pageSize}}
+
+	for {
+		users, resp, err := organizationClient.client.Repositories.ListCollaborators(g.Context, organizationClient.organization, repository, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, user := range users {
+			addCollaborator(user, toGitHubRepositoryPermission(user.Permissions, ""))
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	// Collaborators Derived from Teams
+	teamOpts := &github.ListOptions{PerPage: pageSize}
+
+	for {
+		teams, resp, err := organizationClient.client.Repositories.ListTeams(g.Context, organizationClient.organization, repository, teamOpts)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, team := range teams {
+			teamMembers, err := g.listTeamMembers(organizationClient, team.ID, &organizationID, redhatcopv1beta1.AllGitHubTeamRole)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			for _, teamMember := range teamMembers {
+				addCollaborator(teamMember, toGitHubRepositoryPermission(team.Permissions, team.GetPermission()))
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		teamOpts.Page = resp.NextPage
+	}
+
+	return collaborators, permissions, nil
+}
+
+// toGitHubRepositoryPermission returns the highest permission level reported by the REST API
+func toGitHubRepositoryPermission(permissions map[string]bool, permission string) redhatcopv1beta1.GitHubRepositoryPermission {
+	highest := gitHubRepositoryPermissionKeys[permission]
+
+	for key, granted := range permissions {
+		if granted && gitHubRepositoryPermissionLevel(gitHubRepositoryPermissionKeys[key]) > gitHubRepositoryPermissionLevel(highest) {
+			highest = gitHubRepositoryPermissionKeys[key]
+		}
+	}
+
+	return highest
+}
+
+// gitHubRepositoryPermissionLevel returns the rank of a permission level, where unknown permissions rank lowest
+func gitHubRepositoryPermissionLevel(permission redhatcopv1beta1.GitHubRepositoryPermission) int {
+	for level, candidate := range gitHubRepositoryPermissions {
+		if candidate == permission {
+			return level + 1
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,783 @@
+package syncer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v45/github"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	"github.com/shurcooL/githubv4"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGitHubGroupName(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider redhatcopv1beta1.GitHubProvider
+		expected string
+	}{
+		{
+			name:     "single organization",
+			provider: redhatcopv1beta1.GitHubProvider{Organization: "ocp"},
+			expected: "admins",
+		},
+		{
+			name:     "duplicate organization",
+			provider: redhatcopv1beta1.GitHubProvider{Organization: "ocp", Organizations: []string{"ocp"}},
+			expected: "admins",
+		},
+		{
+			name:     "multiple organizations",
+			provider: redhatcopv1beta1.GitHubProvider{Organizations: []string{"ocp", "rhel"}},
+			expected: "ocp-admins",
+		},
+		{
+			name:     "all organizations",
+			provider: redhatcopv1beta1.GitHubProvider{AllOrganizations: true},
+			expected: "ocp-admins",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitHubSyncer := &GitHubSyncer{Provider: &tt.provider}
+
+			if actual := gitHubSyncer.getGroupName("ocp", "admins"); actual != tt.expected {
+				t.Errorf("getGroupName() = %s, expected %s", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitHubValidateOrganizations(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("unable to build scheme: %v", err)
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: testNamespace},
+			Data:       map[string][]byte{secretTokenKey: []byte("token")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: testNamespace},
+			Data:       map[string][]byte{privateKey: []byte("key"), appId: []byte("1")},
+		},
+	).Build()
+	reconcilerBase := util.NewReconcilerBase(k8sClient, scheme, nil, nil, k8sClient)
+
+	tests := []struct {
+		name          string
+		secret        string
+		provider      redhatcopv1beta1.GitHubProvider
+		expectedError string
+	}{
+		{
+			name:     "multiple organizations",
+			secret:   "token",
+			provider: redhatcopv1beta1.GitHubProvider{Organizations: []string{"ocp", "rhel"}},
+		},
+		{
+			name:     "all organizations",
+			secret:   "app",
+			provider: redhatcopv1beta1.GitHubProvider{AllOrganizations: true},
+		},
+		{
+			name:          "all organizations with token",
+			secret:        "token",
+			provider:      redhatcopv1beta1.GitHubProvider{AllOrganizations: true},
+			expectedError: "allOrganizations requires authenticating as a GitHub App",
+		},
+		{
+			name:          "all organizations with organization",
+			secret:        "app",
+			provider:      redhatcopv1beta1.GitHubProvider{Organization: "ocp", AllOrganizations: true},
+			expectedError: "allOrganizations cannot be combined with organization or organizations",
+		},
+		{
+			name:          "no organization",
+			secret:        "token",
+			provider:      redhatcopv1beta1.GitHubProvider{},
+			expectedError: "Organization name not provided",
+		},
+		{
+			name:     "enterprise",
+			secret:   "token",
+			provider: redhatcopv1beta1.GitHubProvider{Enterprise: &redhatcopv1beta1.GitHubEnterprise{Slug: "acme"}},
+		},
+		{
+			name:          "enterprise with app",
+			secret:        "app",
+			provider:      redhatcopv1beta1.GitHubProvider{Enterprise: &redhatcopv1beta1.GitHubEnterprise{Slug: "acme"}},
+			expectedError: "enterprise requires authenticating using a token",
+		},
+		{
+			name:          "enterprise with organization",
+			secret:        "token",
+			provider:      redhatcopv1beta1.GitHubProvider{Organization: "ocp", Enterprise: &redhatcopv1beta1.GitHubEnterprise{Slug: "acme"}},
+			expectedError: "enterprise cannot be combined with organization, organizations or allOrganizations",
+		},
+		{
+			name:     "repositories",
+			secret:   "token",
+			provider: redhatcopv1beta1.GitHubProvider{Organization: "ocp", Repositories: &redhatcopv1beta1.GitHubRepositories{Names: []string{"api", "docs"}}},
+		},
+		{
+			name:          "repositories template without permission",
+			secret:        "token",
+			provider:      redhatcopv1beta1.GitHubProvider{Organization: "ocp", Repositories: &redhatcopv1beta1.GitHubRepositories{Names: []string{"api"}, NameTemplate: "{repository}"}},
+			expectedError: "nameTemplate must contain {permission} unless a single permission is synchronized",
+		},
+		{
+			name:          "repositories with enterprise",
+			secret:        "token",
+			provider:      redhatcopv1beta1.GitHubProvider{Enterprise: &redhatcopv1beta1.GitHubEnterprise{Slug: "acme"}, Repositories: &redhatcopv1beta1.GitHubRepositories{}},
+			expectedError: "repositories cannot be combined with enterprise",
+		},
+		{
+			name:     "one scope using graphql",
+			secret:   "token",
+			provider: redhatcopv1beta1.GitHubProvider{Organization: "ocp", API: redhatcopv1beta1.GraphQLGitHubAPI, Scope: redhatcopv1beta1.OneSyncScope},
+		},
+		{
+			name:          "one scope using rest",
+			secret:        "token",
+			provider:      redhatcopv1beta1.GitHubProvider{Organization: "ocp", Scope: redhatcopv1beta1.OneSyncScope},
+			expectedError: "The one scope requires the graphql API",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.provider.CredentialsSecret = &redhatcopv1beta1.ObjectRef{Name: tt.secret, Namespace: testNamespace}
+
+			gitHubSyncer := &GitHubSyncer{
+				Name:           "github",
+				GroupSync:      &redhatcopv1beta1.GroupSync{ObjectMeta: metav1.ObjectMeta{Name: "groupsync", Namespace: testNamespace}},
+				Provider:       &tt.provider,
+				ReconcilerBase: reconcilerBase,
+			}
+			gitHubSyncer.Init()
+
+			err := gitHubSyncer.Validate()
+			if tt.expectedError == "" && err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+			if tt.expectedError != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedError)) {
+				t.Errorf("Validate() error = %v, expected %s", err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestGitHubTeamAllowed(t *testing.T) {
+	platform := &github.Team{ID: github.Int64(1), Name: github.String("Platform Engineering"), Slug: github.String("platform-engineering"), Privacy: github.String("closed")}
+	sre := &github.Team{ID: github.Int64(2), Name: github.String("SRE"), Slug: github.String("sre"), Privacy: github.String("closed"), Parent: platform}
+	oncall := &github.Team{ID: github.Int64(3), Name: github.String("On Call"), Slug: github.String("on-call"), Privacy: github.String("secret"), Parent: sre}
+	teamsByID := map[int64]*github.Team{1: platform, 2: sre, 3: oncall}
+
+	tests := []struct {
+		name     string
+		provider redhatcopv1beta1.GitHubProvider
+		expected []string
+	}{
+		{
+			name:     "no filter",
+			provider: redhatcopv1beta1.GitHubProvider{},
+			expected: []string{"platform-engineering", "sre", "on-call"},
+		},
+		{
+			name:     "filter by slug",
+			provider: redhatcopv1beta1.GitHubProvider{Teams: []string{"sre"}},
+			expected: []string{"sre"},
+		},
+		{
+			name:     "filter subtree by slug",
+			provider: redhatcopv1beta1.GitHubProvider{Teams: []string{"sre"}, Scope: redhatcopv1beta1.SubSyncScope},
+			expected: []string{"sre", "on-call"},
+		},
+		{
+			name:     "filter subtree by name",
+			provider: redhatcopv1beta1.GitHubProvider{Teams: []string{"Platform Engineering"}, Scope: redhatcopv1beta1.SubSyncScope},
+			expected: []string{"platform-engineering", "sre", "on-call"},
+		},
+		{
+			name:     "filter by glob pattern",
+			provider: redhatcopv1beta1.GitHubProvider{TeamPatterns: &redhatcopv1beta1.PatternFilter{Include: []string{"platform-*", "s?e"}}},
+			expected: []string{"platform-engineering", "sre"},
+		},
+		{
+			name:     "filter by regex pattern",
+			provider: redhatcopv1beta1.GitHubProvider{TeamPatterns: &redhatcopv1beta1.PatternFilter{Include: []string{"(sre|on-call)"}, Syntax: redhatcopv1beta1.RegexPatternSyntax}},
+			expected: []string{"sre", "on-call"},
+		},
+		{
+			name:     "exclude within subtree",
+			provider: redhatcopv1beta1.GitHubProvider{Teams: []string{"platform-engineering"}, TeamPatterns: &redhatcopv1beta1.PatternFilter{Exclude: []string{"On *"}}, Scope: redhatcopv1beta1.SubSyncScope},
+			expected: []string{"platform-engineering", "sre"},
+		},
+		{
+			name:     "filter by privacy",
+			provider: redhatcopv1beta1.GitHubProvider{TeamPrivacy: []redhatcopv1beta1.GitHubTeamPrivacy{redhatcopv1beta1.SecretGitHubTeamPrivacy}},
+			expected: []string{"on-call"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			teamFilter, err := newGroupNameFilter(tt.provider.Teams, tt.provider.TeamPatterns)
+			if err != nil {
+				t.Fatalf("newGroupNameFilter() error = %v", err)
+			}
+
+			gitHubSyncer := &GitHubSyncer{Provider: &tt.provider, teamFilter: teamFilter}
+
+			actual := []string{}
+			for _, team := range []*github.Team{platform, sre, oncall} {
+				if gitHubSyncer.isTeamAllowed("ocp", team, teamsByID) {
+					actual = append(actual, team.GetSlug())
+				}
+			}
+
+			if strings.Join(actual, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("isTeamAllowed() = %v, expected %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitHubSyncOrganizationRoles(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orgs/ocp", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1, "login": "ocp"}`)
+	})
+	mux.HandleFunc("/orgs/ocp/teams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": 10, "name": "admins", "slug": "admins"}]`)
+	})
+	mux.HandleFunc("/organizations/1/team/10/members", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("role") {
+		case "maintainer":
+			fmt.Fprint(w, `[{"login": "jane"}]`)
+		default:
+			fmt.Fprint(w, `[{"login": "jane"}, {"login": "john"}]`)
+		}
+	})
+	mux.HandleFunc("/orgs/ocp/members", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("role") != "admin" {
+			t.Errorf("unexpected organization member role '%s'", r.URL.Query().Get("role"))
+		}
+		fmt.Fprint(w, `[{"login": "jim"}]`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		provider redhatcopv1beta1.GitHubProvider
+		expected map[string][]string
+	}{
+		{
+			name:     "all members",
+			provider: redhatcopv1beta1.GitHubProvider{Organization: "ocp"},
+			expected: map[string][]string{"admins": {"jane", "john"}},
+		},
+		{
+			name:     "maintainers only",
+			provider: redhatcopv1beta1.GitHubProvider{Organization: "ocp", TeamMemberRole: redhatcopv1beta1.MaintainerGitHubTeamRole},
+			expected: map[string][]string{"admins": {"jane"}},
+		},
+		{
+			name:     "maintainer and owners groups",
+			provider: redhatcopv1beta1.GitHubProvider{Organization: "ocp", MaintainerGroups: true, OwnersGroup: "owners"},
+			expected: map[string][]string{"admins": {"jane", "john"}, "admins-maintainers": {"jane"}, "owners": {"jim"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseURL, _ := url.Parse(server.URL + "/")
+			client := github.NewClient(nil)
+			client.BaseURL = baseURL
+
+			teamFilter, _ := newGroupNameFilter(tt.provider.Teams, tt.provider.TeamPatterns)
+			gitHubSyncer := &GitHubSyncer{Name: "github", Provider: &tt.provider, Context: context.Background(), URL: baseURL, teamFilter: teamFilter}
+
+			groups, err := gitHubSyncer.syncOrganization(gitHubOrganizationClient{organization: "ocp", client: client}, groupHierarchy{})
+			if err != nil {
+				t.Fatalf("syncOrganization() error = %v", err)
+			}
+
+			actual := map[string][]string{}
+			for _, group := range groups {
+				actual[group.Name] = group.Users
+				if group.Annotations[constants.SyncSourceOrg] != "ocp" {
+					t.Errorf("syncOrganization() group '%s' organization annotation = '%s'", group.Name, group.Annotations[constants.SyncSourceOrg])
+				}
+			}
+
+			if fmt.Sprint(actual) != fmt.Sprint(tt.expected) {
+				t.Errorf("syncOrganization() = %v, expected %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitHubSyncOrganizationNestedTeams(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orgs/ocp", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1, "login": "ocp"}`)
+	})
+	mux.HandleFunc("/orgs/ocp/teams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": 10, "name": "platform", "slug": "platform"}, {"id": 11, "name": "sre", "slug": "sre", "parent": {"id": 10, "name": "platform", "slug": "platform"}}]`)
+	})
+	// The REST API includes the members of child teams
+	mux.HandleFunc("/organizations/1/team/10/members", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"login": "jane"}, {"login": "john"}]`)
+	})
+	mux.HandleFunc("/organizations/1/team/11/members", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"login": "john"}]`)
+	})
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected GraphQL query when using the REST API")
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		provider redhatcopv1beta1.GitHubProvider
+		expected map[string][]string
+	}{
+		{
+			// Providers created before scopes were introduced continue to include the members of child teams
+			name:     "no scope",
+			provider: redhatcopv1beta1.GitHubProvider{Organization: "ocp"},
+			expected: map[string][]string{"platform": {"jane", "john"}, "sre": {"john"}},
+		},
+		{
+			name:     "no scope with teams",
+			provider: redhatcopv1beta1.GitHubProvider{Organization: "ocp", Teams: []string{"platform"}},
+			expected: map[string][]string{"platform": {"jane", "john"}},
+		},
+		{
+			name:     "sub scope with teams",
+			provider: redhatcopv1beta1.GitHubProvider{Organization: "ocp", Teams: []string{"platform"}, Scope: redhatcopv1beta1.SubSyncScope},
+			expected: map[string][]string{"platform": {"jane", "john"}, "sre": {"john"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseURL, _ := url.Parse(server.URL + "/")
+			client := github.NewClient(nil)
+			client.BaseURL = baseURL
+
+			teamFilter, _ := newGroupNameFilter(tt.provider.Teams, tt.provider.TeamPatterns)
+			gitHubSyncer := &GitHubSyncer{Name: "github", Provider: &tt.provider, Context: context.Background(), URL: baseURL, teamFilter: teamFilter}
+
+			groups, err := gitHubSyncer.syncOrganization(gitHubOrganizationClient{organization: "ocp", client: client, v4Client: githubv4.NewEnterpriseClient(server.URL+"/graphql", nil)}, groupHierarchy{})
+			if err != nil {
+				t.Fatalf("syncOrganization() error = %v", err)
+			}
+
+			actual := map[string][]string{}
+			for _, group := range groups {
+				actual[group.Name] = group.Users
+			}
+
+			if fmt.Sprint(actual) != fmt.Sprint(tt.expected) {
+				t.Errorf("syncOrganization() = %v, expected %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitHubSyncOrganizationRepositories(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orgs/ocp", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1, "login": "ocp"}`)
+	})
+	mux.HandleFunc("/orgs/ocp/repos", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": 100, "name": "api"}, {"id": 101, "name": "docs"}]`)
+	})
+	mux.HandleFunc("/repos/ocp/api/collaborators", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("affiliation") != "direct" {
+			t.Errorf("unexpected collaborator affiliation '%s'", r.URL.Query().Get("affiliation"))
+		}
+		fmt.Fprint(w, `[{"login": "jane", "permissions": {"admin": true, "maintain": true, "push": true, "triage": true, "pull": true}}, {"login": "jim", "permissions": {"pull": true}}]`)
+	})
+	mux.HandleFunc("/repos/ocp/api/teams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": 10, "name": "developers", "slug": "developers", "permission": "push"}]`)
+	})
+	mux.HandleFunc("/organizations/1/team/10/members", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"login": "jim"}, {"login": "john"}]`)
+	})
+	mux.HandleFunc("/repos/ocp/docs/collaborators", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"login": "jane", "permissions": {"triage": true, "pull": true}}]`)
+	})
+	mux.HandleFunc("/repos/ocp/docs/teams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		repositories redhatcopv1beta1.GitHubRepositories
+		expected     map[string][]string
+	}{
+		{
+			name:         "all permissions",
+			repositories: redhatcopv1beta1.GitHubRepositories{Names: []string{"api"}},
+			expected: map[string][]string{
+				"api-read":     {"jane", "jim", "john"},
+				"api-triage":   {"jane", "jim", "john"},
+				"api-write":    {"jane", "jim", "john"},
+				"api-maintain": {"jane"},
+				"api-admin":    {"jane"},
+			},
+		},
+		{
+			name: "selected permissions with template",
+			repositories: redhatcopv1beta1.GitHubRepositories{
+				Patterns:     &redhatcopv1beta1.PatternFilter{Include: []string{"*"}},
+				Permissions:  []redhatcopv1beta1.GitHubRepositoryPermission{redhatcopv1beta1.TriageGitHubRepositoryPermission, redhatcopv1beta1.AdminGitHubRepositoryPermission},
+				NameTemplate: "{organization}-{repository}-{permission}s",
+			},
+			expected: map[string][]string{
+				"ocp-api-triages":  {"jane", "jim", "john"},
+				"ocp-api-admins":   {"jane"},
+				"ocp-docs-triages": {"jane"},
+				"ocp-docs-admins":  {},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseURL, _ := url.Parse(server.URL + "/")
+			client := github.NewClient(nil)
+			client.BaseURL = baseURL
+
+			gitHubSyncer := &GitHubSyncer{Name: "github", Provider: &redhatcopv1beta1.GitHubProvider{Organization: "ocp", Repositories: &tt.repositories}, Context: context.Background(), URL: baseURL}
+			if errs := gitHubSyncer.validateRepositories(); len(errs) > 0 {
+				t.Fatalf("validateRepositories() errors = %v", errs)
+			}
+
+			groups, err := gitHubSyncer.syncOrganizationRepositories(gitHubOrganizationClient{organization: "ocp", client: client})
+			if err != nil {
+				t.Fatalf("syncOrganizationRepositories() error = %v", err)
+			}
+
+			actual := map[string][]string{}
+			for _, group := range groups {
+				actual[group.Name] = group.Users
+				if group.Annotations[constants.SyncSourceRepo] == "" {
+					t.Errorf("syncOrganizationRepositories() group '%s' missing repository annotation", group.Name)
+				}
+			}
+
+			if fmt.Sprint(actual) != fmt.Sprint(tt.expected) {
+				t.Errorf("syncOrganizationRepositories() = %v, expected %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitHubSyncOrganizationGraphQL(t *testing.T) {
+	queries := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orgs/ocp", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1, "login": "ocp"}`)
+	})
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		queries++
+
+		var request struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf("unable to decode query: %v", err)
+		}
+
+		if request.Variables["membership"] != "IMMEDIATE" {
+			t.Errorf("unexpected team membership %v", request.Variables["membership"])
+		}
+
+		// Serve the recorded response matching the query
+		response := "graphql_teams_page_1.json"
+		switch {
+		case strings.Contains(request.Query, "team(slug: $slug)"):
+			if request.Variables["slug"] != "platform" || request.Variables["after"] != "Y3Vyc29yOnYyOpHOAAAAAQ==" {
+				t.Errorf("unexpected team members query variables %v", request.Variables)
+			}
+			response = "graphql_team_members.json"
+		case request.Variables["after"] != nil:
+			response = "graphql_teams_page_2.json"
+		}
+
+		data, err := os.ReadFile(filepath.Join("testdata", "github", response))
+		if err != nil {
+			t.Fatalf("unable to read recorded response: %v", err)
+		}
+		w.Write(data)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := github.NewClient(nil)
+	client.BaseURL = baseURL
+
+	gitHubSyncer := &GitHubSyncer{
+		Name:       "github",
+		Provider:   &redhatcopv1beta1.GitHubProvider{Organization: "ocp", API: redhatcopv1beta1.GraphQLGitHubAPI, Scope: redhatcopv1beta1.OneSyncScope, MaintainerGroups: true},
+		Context:    context.Background(),
+		URL:        baseURL,
+		teamFilter: &groupNameFilter{},
+	}
+
+	hierarchy := groupHierarchy{}
+	groups, err := gitHubSyncer.syncOrganization(gitHubOrganizationClient{organization: "ocp", client: client, v4Client: githubv4.NewEnterpriseClient(server.URL+"/graphql", nil)}, hierarchy)
+	if err != nil {
+		t.Fatalf("syncOrganization() error = %v", err)
+	}
+
+	if children := hierarchy.children("platform"); fmt.Sprint(children) != "[sre]" {
+		t.Errorf("syncOrganization() children of platform = %v, expected [sre]", children)
+	}
+
+	if queries != 3 {
+		t.Errorf("syncOrganization() performed %d queries, expected 3", queries)
+	}
+
+	actual := map[string][]string{}
+	for _, group := range groups {
+		actual[group.Name] = group.Users
+	}
+
+	expected := map[string][]string{"platform": {"jane", "john"}, "platform-maintainers": {"jane"}, "sre": {"jim", "joe"}, "sre-maintainers": {"jim"}}
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf("syncOrganization() = %v, expected %v", actual, expected)
+	}
+}
+
+func TestGitHubTeamMembershipType(t *testing.T) {
+	tests := []struct {
+		scope    redhatcopv1beta1.SyncScope
+		expected githubv4.TeamMembershipType
+	}{
+		{scope: "", expected: githubv4.TeamMembershipTypeAll},
+		{scope: redhatcopv1beta1.OneSyncScope, expected: githubv4.TeamMembershipTypeImmediate},
+		{scope: redhatcopv1beta1.SubSyncScope, expected: githubv4.TeamMembershipTypeAll},
+	}
+
+	for _, tt := range tests {
+		gitHubSyncer := &GitHubSyncer{Provider: &redhatcopv1beta1.GitHubProvider{Scope: tt.scope}}
+
+		if actual := gitHubSyncer.getTeamMembershipType(); actual != tt.expected {
+			t.Errorf("getTeamMembershipType() scope '%s' = %s, expected %s", tt.scope, actual, tt.expected)
+		}
+	}
+}
+
+func TestGitHubGetUsers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := os.ReadFile(filepath.Join("testdata", "github", "graphql_verified_emails.json"))
+		if err != nil {
+			t.Fatalf("unable to read recorded response: %v", err)
+		}
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		provider      redhatcopv1beta1.GitHubProvider
+		logins        []string
+		expected      []string
+		expectedError string
+	}{
+		{
+			name:     "login",
+			provider: redhatcopv1beta1.GitHubProvider{},
+			logins:   []string{"jane", "john_acme"},
+			expected: []string{"jane", "john_acme"},
+		},
+		{
+			name:     "verified email",
+			provider: redhatcopv1beta1.GitHubProvider{UserMapping: redhatcopv1beta1.VerifiedEmailGitHubUserMapping},
+			logins:   []string{"jane", "john", "jim"},
+			expected: []string{"jane@example.org", "john@example.org"},
+		},
+		{
+			name:     "verified email domain",
+			provider: redhatcopv1beta1.GitHubProvider{UserMapping: redhatcopv1beta1.VerifiedEmailGitHubUserMapping, VerifiedEmailDomain: "example.com"},
+			logins:   []string{"jane", "john"},
+			expected: []string{"jane.doe@example.com"},
+		},
+		{
+			name:     "verified email retain unmapped",
+			provider: redhatcopv1beta1.GitHubProvider{UserMapping: redhatcopv1beta1.VerifiedEmailGitHubUserMapping, UnmappedUserPolicy: redhatcopv1beta1.RetainUnmappedUserPolicy},
+			logins:   []string{"jane", "jim"},
+			expected: []string{"jane@example.org", "jim"},
+		},
+		{
+			name:          "verified email fail unmapped",
+			provider:      redhatcopv1beta1.GitHubProvider{UserMapping: redhatcopv1beta1.VerifiedEmailGitHubUserMapping, UnmappedUserPolicy: redhatcopv1beta1.FailUnmappedUserPolicy},
+			logins:        []string{"jane", "jim"},
+			expectedError: "Unable to map GitHub users using verifiedEmail: jim",
+		},
+		{
+			name:     "emu",
+			provider: redhatcopv1beta1.GitHubProvider{UserMapping: redhatcopv1beta1.EMUGitHubUserMapping},
+			logins:   []string{"jane_acme", "john_doe_acme", "jim"},
+			expected: []string{"jane", "john_doe"},
+		},
+		{
+			name:     "emu shortcode",
+			provider: redhatcopv1beta1.GitHubProvider{UserMapping: redhatcopv1beta1.EMUGitHubUserMapping, EMUShortcode: "acme"},
+			logins:   []string{"jane_acme", "john_other"},
+			expected: []string{"jane"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitHubSyncer := &GitHubSyncer{Name: "github", Provider: &tt.provider, Context: context.Background()}
+			organizationClient := gitHubOrganizationClient{organization: "ocp", v4Client: githubv4.NewEnterpriseClient(server.URL, nil)}
+
+			userMap, err := gitHubSyncer.getUserMap(organizationClient)
+			if err != nil {
+				t.Fatalf("getUserMap() error = %v", err)
+			}
+
+			members := []*github.User{}
+			for _, login := range tt.logins {
+				members = append(members, &github.User{Login: github.String(login)})
+			}
+
+			actual, err := gitHubSyncer.getUsers(members, userMap)
+			if tt.expectedError != "" {
+				if err == nil || err.Error() != tt.expectedError {
+					t.Errorf("getUsers() error = %v, expected %s", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("getUsers() error = %v", err)
+			}
+
+			if fmt.Sprint(actual) != fmt.Sprint(tt.expected) {
+				t.Errorf("getUsers() = %v, expected %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitHubSyncEnterprise(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/scim/v2/enterprises/acme/Users", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != scimMediaType {
+			t.Errorf("unexpected SCIM media type '%s'", r.Header.Get("Accept"))
+		}
+
+		// Return a single user per page to exercise pagination
+		switch r.URL.Query().Get("startIndex") {
+		case "1":
+			fmt.Fprint(w, `{"totalResults": 2, "startIndex": 1, "Resources": [{"id": "u1", "userName": "jane@example.com"}]}`)
+		default:
+			fmt.Fprint(w, `{"totalResults": 2, "startIndex": 2, "Resources": [{"id": "u2", "userName": "john@example.com"}]}`)
+		}
+	})
+	mux.HandleFunc("/scim/v2/enterprises/acme/Groups", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"totalResults": 2, "startIndex": 1, "Resources": [
+			{"id": "g1", "displayName": "admins", "members": [{"value": "u1"}, {"value": "u3"}]},
+			{"id": "g2", "displayName": "developers", "members": [{"value": "u2"}]}
+		]}`)
+	})
+	mux.HandleFunc("/enterprises/acme/teams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": 5, "name": "Platform", "slug": "platform"}]`)
+	})
+	mux.HandleFunc("/enterprises/acme/teams/platform/memberships", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"login": "jane_acme"}, {"login": "john_acme"}]`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		provider      redhatcopv1beta1.GitHubProvider
+		expected      map[string][]string
+		expectedError string
+	}{
+		{
+			name:     "scim groups",
+			provider: redhatcopv1beta1.GitHubProvider{Enterprise: &redhatcopv1beta1.GitHubEnterprise{Slug: "acme"}},
+			expected: map[string][]string{"admins": {"jane@example.com"}, "developers": {"john@example.com"}},
+		},
+		{
+			name:          "scim groups fail unknown user",
+			provider:      redhatcopv1beta1.GitHubProvider{Enterprise: &redhatcopv1beta1.GitHubEnterprise{Slug: "acme"}, UnmappedUserPolicy: redhatcopv1beta1.FailUnmappedUserPolicy},
+			expectedError: "Unable to locate SCIM user 'u3' of group 'admins'",
+		},
+		{
+			name:     "teams",
+			provider: redhatcopv1beta1.GitHubProvider{Enterprise: &redhatcopv1beta1.GitHubEnterprise{Slug: "acme", Source: redhatcopv1beta1.TeamsGitHubEnterpriseSource}, UserMapping: redhatcopv1beta1.EMUGitHubUserMapping},
+			expected: map[string][]string{"Platform": {"jane", "john"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseURL, _ := url.Parse(server.URL + "/")
+			client := github.NewClient(nil)
+			client.BaseURL = baseURL
+
+			teamFilter, _ := newGroupNameFilter(tt.provider.Teams, tt.provider.TeamPatterns)
+			gitHubSyncer := &GitHubSyncer{Name: "github", Provider: &tt.provider, Context: context.Background(), URL: baseURL, enterpriseClient: client, teamFilter: teamFilter}
+
+			groups, err := gitHubSyncer.Sync()
+			if tt.expectedError != "" {
+				if err == nil || err.Error() != tt.expectedError {
+					t.Errorf("Sync() error = %v, expected %s", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Sync() error = %v", err)
+			}
+
+			actual := map[string][]string{}
+			for _, group := range groups {
+				actual[group.Name] = group.Users
+			}
+
+			if fmt.Sprint(actual) != fmt.Sprint(tt.expected) {
+				t.Errorf("Sync() = %v, expected %v", actual, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package syncer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v45/github"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	"github.com/shurcooL/githubv4"
+)
+
+// gitHubVerifiedEmailsQuery retrieves a page of the members of an organization along with their email addresses in
+// the verified domains of the organization
+type gitHubVerifiedEmailsQuery struct {
+	Organization struct {
+		MembersWithRole struct {
+			PageInfo gitHubPageInfo
+			Nodes    []struct {
+				Login                            githubv4.String
+				OrganizationVerifiedDomainEmails []githubv4.String `graphql:"organizationVerifiedDomainEmails(login: $organization)"`
+			}
+		} `graphql:"membersWithRole(first: $first, after: $after)"`
+	} `graphql:"organization(login: $organization)"`
+}
+
+// getUserMapping returns how GitHub logins are mapped to user names
+func (g *GitHubSyncer) getUserMapping() redhatcopv1beta1.GitHubUserMapping {
+	if g.Provider.UserMapping != "" {
+		return g.Provider.UserMapping
+	}
+
+	if g.Provider.MapByScimId {
+		return redhatcopv1beta1.ScimIdGitHubUserMapping
+	}
+
+	return redhatcopv1beta1.LoginGitHubUserMapping
+}
+
+// getUserMap returns the user name of each member of an organization keyed by login for mappings that require
+// retrieving the identity of users from GitHub
+func (g *GitHubSyncer) getUserMap(organizationClient gitHubOrganizationClient) (map[string]string, error) {
+	switch g.getUserMapping() {
+	case redhatcopv1beta1.ScimIdGitHubUserMapping:
+		return g.getScimIdentity(organizationClient)
+	case redhatcopv1beta1.VerifiedEmailGitHubUserMapping:
+		return g.getVerifiedEmails(organizationClient)
+	default:
+		return nil, nil
+	}
+}
+
+// getVerifiedEmails returns the email address in a verified domain of the organization of each member, keyed by login
+func (g *GitHubSyncer) getVerifiedEmails(organizationClient gitHubOrganizationClient) (map[string]string, error) {
+	variables := map[string]interface{}{
+		"organization": githubv4.String(organizationClient.organization),
+		"first":        githubv4.Int(pageSize),
+		"after":        (*githubv4.String)(nil),
+	}
+
+	userMap := map[string]string{}
+
+	for {
+		var query gitHubVerifiedEmailsQuery
+		if err := organizationClient.v4Client.Query(g.Context, &query, variables); err != nil {
+			return nil, err
+		}
+
+		for _, node := range query.Organization.MembersWithRole.Nodes {
+			for _, email := range node.OrganizationVerifiedDomainEmails {
+				if g.Provider.VerifiedEmailDomain == "" || strings.HasSuffix(strings.ToLower(string(email)), "@"+strings.ToLower(g.Provider.VerifiedEmailDomain)) {
+					userMap[string(node.Login)] = string(email)
+					break
+				}
+			}
+		}
+
+		if !query.Organization.MembersWithRole.PageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = githubv4.NewString(query.Organization.MembersWithRole.PageInfo.EndCursor)
+	}
+
+	return userMap, nil
+}
+
+// mapUser returns the user name of a GitHub login and whether the login could be mapped
+func (g *GitHubSyncer) mapUser(login string, userMap map[string]string) (string, bool) {
+	switch g.getUserMapping() {
+	case redhatcopv1beta1.ScimIdGitHubUserMapping, redhatcopv1beta1.VerifiedEmailGitHubUserMapping:
+		userName, found := userMap[login]
+		return userName, found && userName != ""
+	case redhatcopv1beta1.EMUGitHubUserMapping:
+		if g.Provider.EMUShortcode != "" {
+			userName, found := strings.CutSuffix(login, "_"+g.Provider.EMUShortcode)
+			return userName, found && userName != ""
+		}
+
+		if separator := strings.LastIndex(login, "_"); separator > 0 {
+			return login[:separator], true
+		}
+
+		return "", false
+	default:
+		return login, true
+	}
+}
+
+// getUsers returns the user names of the provided GitHub users. Users that cannot be mapped are handled according to
+// the unmapped user policy of the provider
+func (g *GitHubSyncer) getUsers(members []*github.User, userMap map[string]string) ([]string, error) {
+	users := []string{}
+	unmappedUsers := []string{}
+
+	for _, member := range members {
+		userName, mapped := g.mapUser(member.GetLogin(), userMap)
+
+		if mapped {
+			users = append(users, userName)
+			continue
+		}
+
+		switch g.Provider.UnmappedUserPolicy {
+		case redhatcopv1beta1.RetainUnmappedUserPolicy:
+			users = append(users, member.GetLogin())
+		case redhatcopv1beta1.FailUnmappedUserPolicy:
+			unmappedUsers = append(unmappedUsers, member.GetLogin())
+		default:
+			gitHubLogger.Info("Skipping Unmapped User", "Login", member.GetLogin(), "Mapping", g.getUserMapping(), "Provider", g.Name)
+		}
+	}
+
+	if len(unmappedUsers) > 0 {
+		return nil, fmt.Errorf("Unable to map GitHub users using %s: %s", g.getUserMapping(), strings.Join(unmappedUsers, ", "))
+	}
+
+	return users, nil
+}
@@ -2,16 +2,14 @@ package syncer
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
 	"fmt"
-	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
-	"github.com/hashicorp/go-cleanhttp"
 	userv1 "github.com/openshift/api/user/v1"
-	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
 	"github.com/redhat-cop/group-sync-operator/pkg/constants"
 	"github.com/xanzy/go-gitlab"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -19,7 +17,6 @@ import (
 	"github.com/redhat-cop/operator-utils/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
@@ -35,16 +32,27 @@ const (
 	OAuthGitLabTokenType    GitLabTokenType = "oauth"
 )
 
+// gitLabAccessLevels are the access levels of group members supported by the provider
+var gitLabAccessLevels = map[redhatcopv1beta1.GitLabAccessLevel]gitlab.AccessLevelValue{
+	redhatcopv1beta1.GuestGitLabAccessLevel:      gitlab.GuestPermissions,
+	redhatcopv1beta1.ReporterGitLabAccessLevel:   gitlab.ReporterPermissions,
+	redhatcopv1beta1.DeveloperGitLabAccessLevel:  gitlab.DeveloperPermissions,
+	redhatcopv1beta1.MaintainerGitLabAccessLevel: gitlab.MaintainerPermissions,
+	redhatcopv1beta1.OwnerGitLabAccessLevel:      gitlab.OwnerPermissions,
+}
+
 type GitLabSyncer struct {
 	Name              string
-	GroupSync         *redhatcopv1alpha1.GroupSync
-	Provider          *redhatcopv1alpha1.GitLabProvider
+	GroupSync         *redhatcopv1beta1.GroupSync
+	Provider          *redhatcopv1beta1.GitLabProvider
 	Client            *gitlab.Client
 	Context           context.Context
 	ReconcilerBase    util.ReconcilerBase
 	CredentialsSecret *corev1.Secret
 	URL               *url.URL
-	CaCertificate     []byte
+	httpClientConfig  *httpClientConfig
+	groupFilter       *groupNameFilter
+	users             map[int]*gitlab.User
 }
 
 func (g *GitLabSyncer) Init() bool {
@@ -52,7 +60,7 @@ func (g *GitLabSyncer) Init() bool {
 	g.Context = context.Background()
 
 	if g.Provider.Scope == "" {
-		g.Provider.Scope = redhatcopv1alpha1.SubSyncScope
+		g.Provider.Scope = redhatcopv1beta1.SubSyncScope
 		return true
 	}
 
@@ -64,8 +72,7 @@ func (g *GitLabSyncer) Validate() error {
 	validationErrors := []error{}
 
 	if g.Provider.CredentialsSecret != nil {
-		credentialsSecret := &corev1.Secret{}
-		err := g.ReconcilerBase.GetClient().Get(context.TODO(), types.NamespacedName{Name: g.Provider.CredentialsSecret.Name, Namespace: g.Provider.CredentialsSecret.Namespace}, credentialsSecret)
+		credentialsSecret, err := getCredentialsSecret(context.TODO(), g.ReconcilerBase.GetClient(), g.GroupSync, g.Provider.CredentialsSecret)
 
 		if err != nil {
 			validationErrors = append(validationErrors, err)
@@ -87,37 +94,31 @@ func (g *GitLabSyncer) Validate() error {
 		validationErrors = append(validationErrors, fmt.Errorf("credentialsSecret must be provided for GitLab provider"))
 	}
 
-	providerCaResource := determineFromDeprecatedObjectRef(g.Provider.Ca, g.Provider.CaSecret)
-	if providerCaResource != nil {
-
-		caResource, err := getObjectRefData(g.Context, g.ReconcilerBase.GetClient(), providerCaResource)
-
-		if err != nil {
-			validationErrors = append(validationErrors, err)
-		}
-
-		var resourceCaKey string
-		if providerCaResource.Key != "" {
-			resourceCaKey = providerCaResource.Key
-		} else {
-			resourceCaKey = defaultResourceCaKey
-		}
+	httpClientConfig, err := newHTTPClientConfig(g.Context, g.ReconcilerBase.GetClient(), g.GroupSync, g.Provider.ProviderBase, g.Provider.HTTPClientOptions)
+	if err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+	g.httpClientConfig = httpClientConfig
 
-		// Certificate key validation
-		if _, found := caResource[resourceCaKey]; !found {
-			validationErrors = append(validationErrors, fmt.Errorf("Could not find '%s' key in %s '%s' in namespace '%s'", resourceCaKey, providerCaResource.Kind, providerCaResource.Name, providerCaResource.Namespace))
-		}
+	groupFilter, err := newGroupNameFilter(g.Provider.Groups, g.Provider.GroupPatterns)
+	if err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+	g.groupFilter = groupFilter
 
-		g.CaCertificate = caResource[resourceCaKey]
+	if g.Provider.IdentityProvider != "" && g.getUserMapping() != redhatcopv1beta1.IdentityGitLabUserMapping {
+		validationErrors = append(validationErrors, fmt.Errorf("identityProvider requires userMapping '%s'", redhatcopv1beta1.IdentityGitLabUserMapping))
 	}
 
-	if g.Provider.URL != nil {
+	validationErrors = append(validationErrors, g.validateAccessLevelGroups()...)
+
+	if g.Provider.URL != "" {
 
 		var err error
-		g.URL, err = url.Parse(*g.Provider.URL)
+		g.URL, err = url.Parse(g.Provider.URL)
 
 		if err != nil {
-			validationErrors = append(validationErrors, fmt.Errorf("Invalid GitLab URL: '%s'", *g.Provider.URL))
+			validationErrors = append(validationErrors, fmt.Errorf("Invalid GitLab URL: '%s'", g.Provider.URL))
 		}
 
 	}
@@ -141,26 +142,7 @@ func (g *GitLabSyncer) Bind() error {
 		clientFns = append(clientFns, gitlab.WithBaseURL(g.URL.String()))
 	}
 
-	if g.Provider.Insecure == true || len(g.CaCertificate) > 0 {
-
-		transport := cleanhttp.DefaultPooledTransport()
-
-		if g.Provider.Insecure == true {
-			transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-		} else if g.CaCertificate != nil {
-			tlsConfig := &tls.Config{}
-			if tlsConfig.RootCAs == nil {
-				tlsConfig.RootCAs = x509.NewCertPool()
-			}
-
-			tlsConfig.RootCAs.AppendCertsFromPEM(g.CaCertificate)
-
-			transport.TLSClientConfig = tlsConfig
-
-		}
-
-		clientFns = append(clientFns, gitlab.WithHTTPClient(&http.Client{Transport: transport}))
-	}
+	clientFns = append(clientFns, gitlab.WithHTTPClient(g.httpClientConfig.client()))
 
 	if tokenSecretFound {
 
@@ -207,6 +189,7 @@ func (g *GitLabSyncer) Bind() error {
 func (g *GitLabSyncer) Sync() ([]userv1.Group, error) {
 
 	ocpGroups := []userv1.Group{}
+	g.users = map[int]*gitlab.User{}
 
 	groups, err := g.getGroups()
 
@@ -214,47 +197,122 @@ func (g *GitLabSyncer) Sync() ([]userv1.Group, error) {
 		return nil, err
 	}
 
+	groupNames := map[int]string{}
+	for _, group := range groups {
+		groupNames[group.ID] = group.Name
+	}
+
+	hierarchy := groupHierarchy{}
+
 	for _, group := range groups {
 
-		if !isGroupAllowed(group.Name, g.Provider.Groups) {
+		if !g.groupFilter.allows(group.Name) {
 			continue
 		}
 
+		if parentName, found := groupNames[group.ParentID]; found && group.ParentID != 0 {
+			hierarchy.addChild(parentName, group.Name)
+		}
+
 		groupMembers, err := g.getGroupMembers(group.ID, g.Provider.Scope)
 
 		if err != nil {
 			return nil, err
 		}
 
-		ocpGroup := userv1.Group{
-			TypeMeta: v1.TypeMeta{
-				Kind:       "Group",
-				APIVersion: userv1.GroupVersion.String(),
-			},
-			ObjectMeta: v1.ObjectMeta{
-				Name:        group.Name,
-				Annotations: map[string]string{},
-				Labels:      map[string]string{},
-			},
-			Users: []string{},
+		members, err := g.getMembers(groupMembers)
+		if err != nil {
+			return nil, fmt.Errorf("group '%s': %w", group.Name, err)
 		}
 
-		// Set Host Specific Details
-		ocpGroup.GetAnnotations()[constants.SyncSourceHost] = g.URL.Host
-		ocpGroup.GetAnnotations()[constants.SyncSourceUID] = strconv.Itoa(group.ID)
-
-		for _, groupMember := range groupMembers {
-			ocpGroup.Users = append(ocpGroup.Users, groupMember.Username)
+		ocpGroup, err := g.newGroup(group.Name, group.ID, members, gitLabAccessLevels[g.Provider.MinAccessLevel])
+		if err != nil {
+			return nil, err
 		}
 
 		ocpGroups = append(ocpGroups, ocpGroup)
 
+		// Synchronize a Group per Access Level from the Same Members
+		for _, accessLevel := range g.Provider.AccessLevelGroups {
+			ocpGroup, err := g.newGroup(getAccessLevelGroupName(group.Name, accessLevel), group.ID, members, gitLabAccessLevels[accessLevel])
+			if err != nil {
+				return nil, err
+			}
+
+			ocpGroups = append(ocpGroups, ocpGroup)
+		}
+
+	}
+
+	if err := hierarchy.apply(ocpGroups, g.Provider.Hierarchy); err != nil {
+		return nil, err
 	}
 
 	return ocpGroups, nil
 
 }
 
+// newGroup returns a group containing the members with at least the provided access level
+func (g *GitLabSyncer) newGroup(name string, groupID int, members []gitLabMember, minAccessLevel gitlab.AccessLevelValue) (userv1.Group, error) {
+
+	ocpGroup := userv1.Group{
+		TypeMeta: v1.TypeMeta{
+			Kind:       "Group",
+			APIVersion: userv1.GroupVersion.String(),
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{},
+			Labels:      map[string]string{},
+		},
+		Users: []string{},
+	}
+
+	// Set Host Specific Details
+	ocpGroup.GetAnnotations()[constants.SyncSourceHost] = g.URL.Host
+	ocpGroup.GetAnnotations()[constants.SyncSourceUID] = strconv.Itoa(groupID)
+
+	memberExpiry := map[string]time.Time{}
+
+	for _, member := range members {
+		if member.accessLevel < minAccessLevel {
+			continue
+		}
+
+		ocpGroup.Users = append(ocpGroup.Users, member.name)
+
+		// Access expires at the start of the expiration date
+		if member.expiresAt != nil {
+			memberExpiry[member.name] = time.Time(*member.expiresAt)
+		}
+	}
+
+	if err := setMemberExpiry(&ocpGroup, memberExpiry); err != nil {
+		return ocpGroup, err
+	}
+
+	return ocpGroup, nil
+}
+
+// validateAccessLevelGroups ensures that access level groups only contain members that are synchronized according to
+// the minimum access level
+func (g *GitLabSyncer) validateAccessLevelGroups() []error {
+	validationErrors := []error{}
+
+	for _, accessLevel := range g.Provider.AccessLevelGroups {
+		if gitLabAccessLevels[accessLevel] < gitLabAccessLevels[g.Provider.MinAccessLevel] {
+			validationErrors = append(validationErrors, fmt.Errorf("Access level group '%s' is below the minimum access level '%s'", accessLevel, g.Provider.MinAccessLevel))
+		}
+	}
+
+	return validationErrors
+}
+
+// getAccessLevelGroupName returns the name of the group containing the members of a group with an access level
+func getAccessLevelGroupName(groupName string, accessLevel redhatcopv1beta1.GitLabAccessLevel) string {
+	return fmt.Sprintf("%s-%ss", groupName, strings.ToLower(string(accessLevel)))
+}
+
 func (g *GitLabSyncer) getGroups() ([]*gitlab.Group, error) {
 
 	var allGroups []*gitlab.Group
@@ -331,7 +389,7 @@ func (g *GitLabSyncer) getDescendantGroups(groupId int) ([]*gitlab.Group, error)
 
 }
 
-func (g *GitLabSyncer) getGroupMembers(groupId int, scope redhatcopv1alpha1.SyncScope) ([]*gitlab.GroupMember, error) {
+func (g *GitLabSyncer) getGroupMembers(groupId int, scope redhatcopv1beta1.SyncScope) ([]*gitlab.GroupMember, error) {
 
 	groupMembers := []*gitlab.GroupMember{}
 
@@ -348,7 +406,7 @@ func (g *GitLabSyncer) getGroupMembers(groupId int, scope redhatcopv1alpha1.Sync
 		var resp *gitlab.Response
 		var err error
 
-		if redhatcopv1alpha1.SubSyncScope == scope {
+		if redhatcopv1beta1.SubSyncScope == scope {
 			members, resp, err = g.Client.Groups.ListAllGroupMembers(groupId, opt)
 		} else {
 			members, resp, err = g.Client.Groups.ListGroupMembers(groupId, opt)
@@ -0,0 +1,351 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestGitLabSyncAccessLevels(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/groups", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": 1, "name": "platform"}]`)
+	})
+	mux.HandleFunc("/api/v4/groups/1/descendant_groups", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+	mux.HandleFunc("/api/v4/groups/1/members/all", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"id": 1, "username": "jane", "access_level": 50},
+			{"id": 2, "username": "john", "access_level": 40, "expires_at": "2030-01-01"},
+			{"id": 3, "username": "jim", "access_level": 30},
+			{"id": 4, "username": "joan", "access_level": 10}
+		]`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		provider redhatcopv1beta1.GitLabProvider
+		expected map[string][]string
+	}{
+		{
+			name:     "all members",
+			provider: redhatcopv1beta1.GitLabProvider{},
+			expected: map[string][]string{"platform": {"jane", "john", "jim", "joan"}},
+		},
+		{
+			name:     "minimum access level",
+			provider: redhatcopv1beta1.GitLabProvider{MinAccessLevel: redhatcopv1beta1.DeveloperGitLabAccessLevel},
+			expected: map[string][]string{"platform": {"jane", "john", "jim"}},
+		},
+		{
+			// Access level groups contain the members with the access level or higher
+			name: "access level groups",
+			provider: redhatcopv1beta1.GitLabProvider{
+				MinAccessLevel:    redhatcopv1beta1.ReporterGitLabAccessLevel,
+				AccessLevelGroups: []redhatcopv1beta1.GitLabAccessLevel{redhatcopv1beta1.OwnerGitLabAccessLevel, redhatcopv1beta1.MaintainerGitLabAccessLevel, redhatcopv1beta1.DeveloperGitLabAccessLevel},
+			},
+			expected: map[string][]string{
+				"platform":             {"jane", "john", "jim"},
+				"platform-owners":      {"jane"},
+				"platform-maintainers": {"jane", "john"},
+				"platform-developers":  {"jane", "john", "jim"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := gitlab.NewClient("token", gitlab.WithBaseURL(server.URL))
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			serverURL, _ := url.Parse(server.URL)
+			groupFilter, _ := newGroupNameFilter(tt.provider.Groups, tt.provider.GroupPatterns)
+			tt.provider.Scope = redhatcopv1beta1.SubSyncScope
+
+			gitLabSyncer := &GitLabSyncer{Name: "gitlab", Provider: &tt.provider, Client: client, Context: context.Background(), URL: serverURL, groupFilter: groupFilter}
+
+			groups, err := gitLabSyncer.Sync()
+			if err != nil {
+				t.Fatalf("Sync() error = %v", err)
+			}
+
+			actual := map[string][]string{}
+			for _, group := range groups {
+				actual[group.Name] = group.Users
+				if group.Annotations[constants.SyncSourceUID] != "1" {
+					t.Errorf("Sync() group '%s' uid annotation = '%s'", group.Name, group.Annotations[constants.SyncSourceUID])
+				}
+			}
+
+			if fmt.Sprint(actual) != fmt.Sprint(tt.expected) {
+				t.Errorf("Sync() = %v, expected %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitLabValidateAccessLevelGroups(t *testing.T) {
+	tests := []struct {
+		name          string
+		provider      redhatcopv1beta1.GitLabProvider
+		expectedError string
+	}{
+		{
+			name:     "no minimum access level",
+			provider: redhatcopv1beta1.GitLabProvider{AccessLevelGroups: []redhatcopv1beta1.GitLabAccessLevel{redhatcopv1beta1.GuestGitLabAccessLevel}},
+		},
+		{
+			name: "access level group at the minimum access level",
+			provider: redhatcopv1beta1.GitLabProvider{
+				MinAccessLevel:    redhatcopv1beta1.DeveloperGitLabAccessLevel,
+				AccessLevelGroups: []redhatcopv1beta1.GitLabAccessLevel{redhatcopv1beta1.DeveloperGitLabAccessLevel, redhatcopv1beta1.MaintainerGitLabAccessLevel},
+			},
+		},
+		{
+			name: "access level group below the minimum access level",
+			provider: redhatcopv1beta1.GitLabProvider{
+				MinAccessLevel:    redhatcopv1beta1.DeveloperGitLabAccessLevel,
+				AccessLevelGroups: []redhatcopv1beta1.GitLabAccessLevel{redhatcopv1beta1.MaintainerGitLabAccessLevel, redhatcopv1beta1.GuestGitLabAccessLevel},
+			},
+			expectedError: "Access level group 'Guest' is below the minimum access level 'Developer'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitLabSyncer := &GitLabSyncer{Name: "gitlab", Provider: &tt.provider}
+
+			errs := gitLabSyncer.validateAccessLevelGroups()
+			if tt.expectedError == "" && len(errs) > 0 {
+				t.Errorf("validateAccessLevelGroups() errors = %v", errs)
+			}
+			if tt.expectedError != "" && (len(errs) != 1 || errs[0].Error() != tt.expectedError) {
+				t.Errorf("validateAccessLevelGroups() errors = %v, expected %s", errs, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestGitLabSyncMembers(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/groups", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": 1, "name": "platform"}]`)
+	})
+	mux.HandleFunc("/api/v4/groups/1/descendant_groups", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+	mux.HandleFunc("/api/v4/groups/1/members/all", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"id": 1, "username": "jane", "state": "active", "access_level": 40, "group_saml_identity": {"provider": "group_saml", "extern_uid": "jane@example.com"}},
+			{"id": 2, "username": "john", "state": "active", "access_level": 30},
+			{"id": 3, "username": "jim", "state": "blocked", "access_level": 30},
+			{"id": 4, "username": "project_1_bot", "state": "active", "access_level": 30}
+		]`)
+	})
+	users := map[string]string{
+		"1": `{"id": 1, "username": "jane", "identities": [{"provider": "group_saml", "extern_uid": "jane@example.com"}]}`,
+		"2": `{"id": 2, "username": "john", "identities": [{"provider": "ldapmain", "extern_uid": "uid=john,ou=people,dc=example,dc=com"}, {"provider": "saml", "extern_uid": "john@example.com"}]}`,
+		"3": `{"id": 3, "username": "jim", "identities": []}`,
+		"4": `{"id": 4, "username": "project_1_bot", "bot": true, "identities": []}`,
+	}
+	mux.HandleFunc("/api/v4/users/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, users[strings.TrimPrefix(r.URL.Path, "/api/v4/users/")])
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		provider      redhatcopv1beta1.GitLabProvider
+		expected      []string
+		expectedError string
+	}{
+		{
+			name:     "all members",
+			provider: redhatcopv1beta1.GitLabProvider{},
+			expected: []string{"jane", "john", "jim", "project_1_bot"},
+		},
+		{
+			name:     "active members without bots",
+			provider: redhatcopv1beta1.GitLabProvider{MemberStates: []string{"active"}, ExcludeBots: true},
+			expected: []string{"jane", "john"},
+		},
+		{
+			name:     "identity mapping",
+			provider: redhatcopv1beta1.GitLabProvider{MemberStates: []string{"active"}, UserMapping: redhatcopv1beta1.IdentityGitLabUserMapping},
+			expected: []string{"jane@example.com", "uid=john,ou=people,dc=example,dc=com"},
+		},
+		{
+			name:     "identity mapping by provider retaining unmapped users",
+			provider: redhatcopv1beta1.GitLabProvider{UserMapping: redhatcopv1beta1.IdentityGitLabUserMapping, IdentityProvider: "saml", UnmappedUserPolicy: redhatcopv1beta1.RetainUnmappedUserPolicy},
+			expected: []string{"jane", "john@example.com", "jim", "project_1_bot"},
+		},
+		{
+			name:          "identity mapping failing on unmapped users",
+			provider:      redhatcopv1beta1.GitLabProvider{ExcludeBots: true, UserMapping: redhatcopv1beta1.IdentityGitLabUserMapping, UnmappedUserPolicy: redhatcopv1beta1.FailUnmappedUserPolicy},
+			expectedError: "Unable to map GitLab users using identity: jim",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := gitlab.NewClient("token", gitlab.WithBaseURL(server.URL))
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			serverURL, _ := url.Parse(server.URL)
+			groupFilter, _ := newGroupNameFilter(tt.provider.Groups, tt.provider.GroupPatterns)
+			tt.provider.Scope = redhatcopv1beta1.SubSyncScope
+
+			gitLabSyncer := &GitLabSyncer{Name: "gitlab", Provider: &tt.provider, Client: client, Context: context.Background(), URL: serverURL, groupFilter: groupFilter}
+
+			groups, err := gitLabSyncer.Sync()
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("Sync() error = %v, expected %s", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Sync() error = %v", err)
+			}
+
+			if len(groups) != 1 || fmt.Sprint(groups[0].Users) != fmt.Sprint(tt.expected) {
+				t.Errorf("Sync() = %v, expected %v", groups, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitLabSyncUserLookups(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/groups", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": 1, "name": "platform"}, {"id": 2, "name": "apps"}]`)
+	})
+	for _, groupID := range []string{"1", "2"} {
+		mux.HandleFunc("/api/v4/groups/"+groupID+"/descendant_groups", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `[]`)
+		})
+		mux.HandleFunc("/api/v4/groups/"+groupID+"/members/all", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `[
+				{"id": 1, "username": "jane", "state": "active", "access_level": 40},
+				{"id": 2, "username": "project_1_bot", "state": "active", "access_level": 30}
+			]`)
+		})
+	}
+	users := map[string]string{
+		"1": `{"id": 1, "username": "jane", "identities": [{"provider": "saml", "extern_uid": "jane@example.com"}]}`,
+		"2": `{"id": 2, "username": "project_1_bot", "bot": true, "identities": []}`,
+	}
+	userLookups := map[string]int{}
+	mux.HandleFunc("/api/v4/users/", func(w http.ResponseWriter, r *http.Request) {
+		userID := strings.TrimPrefix(r.URL.Path, "/api/v4/users/")
+		userLookups[userID]++
+		fmt.Fprint(w, users[userID])
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := gitlab.NewClient("token", gitlab.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	serverURL, _ := url.Parse(server.URL)
+	provider := redhatcopv1beta1.GitLabProvider{Scope: redhatcopv1beta1.SubSyncScope, ExcludeBots: true, UserMapping: redhatcopv1beta1.IdentityGitLabUserMapping}
+	groupFilter, _ := newGroupNameFilter(provider.Groups, provider.GroupPatterns)
+
+	gitLabSyncer := &GitLabSyncer{Name: "gitlab", Provider: &provider, Client: client, Context: context.Background(), URL: serverURL, groupFilter: groupFilter}
+
+	// Users are looked up once per synchronization regardless of the number of groups they are a member of
+	for i := 0; i < 2; i++ {
+		groups, err := gitLabSyncer.Sync()
+		if err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+
+		if len(groups) != 2 || fmt.Sprint(groups[0].Users) != "[jane@example.com]" || fmt.Sprint(groups[1].Users) != "[jane@example.com]" {
+			t.Errorf("Sync() = %v, expected jane@example.com in both groups", groups)
+		}
+	}
+
+	if fmt.Sprint(userLookups) != "map[1:2 2:2]" {
+		t.Errorf("user lookups = %v, expected one lookup per user and synchronization", userLookups)
+	}
+}
+
+func TestGitLabSyncMemberExpiry(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/groups", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": 1, "name": "platform"}]`)
+	})
+	mux.HandleFunc("/api/v4/groups/1/descendant_groups", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+	mux.HandleFunc("/api/v4/groups/1/members/all", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"id": 1, "username": "jane", "access_level": 30, "expires_at": "2020-01-01", "group_saml_identity": {"provider": "group_saml", "extern_uid": "jane@example.com"}},
+			{"id": 2, "username": "john", "access_level": 30, "expires_at": "2030-01-01", "group_saml_identity": {"provider": "group_saml", "extern_uid": "john@example.com"}},
+			{"id": 3, "username": "jim", "access_level": 30, "group_saml_identity": {"provider": "group_saml", "extern_uid": "jim@example.com"}}
+		]`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := gitlab.NewClient("token", gitlab.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	serverURL, _ := url.Parse(server.URL)
+	groupFilter, _ := newGroupNameFilter(nil, nil)
+	provider := &redhatcopv1beta1.GitLabProvider{Scope: redhatcopv1beta1.SubSyncScope, UserMapping: redhatcopv1beta1.IdentityGitLabUserMapping}
+
+	gitLabSyncer := &GitLabSyncer{Name: "gitlab", Provider: provider, Client: client, Context: context.Background(), URL: serverURL, groupFilter: groupFilter}
+
+	groups, err := gitLabSyncer.Sync()
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	if len(groups) != 1 {
+		t.Fatalf("Sync() = %v, expected a single group", groups)
+	}
+
+	// Expiry is recorded using the names members are synchronized as
+	expectedExpiry := `{"jane@example.com":"2020-01-01T00:00:00Z","john@example.com":"2030-01-01T00:00:00Z"}`
+	if groups[0].Annotations[constants.SyncMemberExpiry] != expectedExpiry {
+		t.Errorf("Sync() member expiry = %s, expected %s", groups[0].Annotations[constants.SyncMemberExpiry], expectedExpiry)
+	}
+
+	nextExpiry, err := RemoveExpiredMembers(groups, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("RemoveExpiredMembers() error = %v", err)
+	}
+
+	if fmt.Sprint(groups[0].Users) != "[john@example.com jim@example.com]" {
+		t.Errorf("RemoveExpiredMembers() users = %v, expected the expired member to be removed", groups[0].Users)
+	}
+	if nextExpiry == nil || !nextExpiry.Equal(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("RemoveExpiredMembers() next expiry = %v", nextExpiry)
+	}
+}
@@ -0,0 +1,122 @@
+package syncer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	"github.com/xanzy/go-gitlab"
+)
+
+// gitLabMember represents a member of a group along with the user name the member is synchronized as
+type gitLabMember struct {
+	name        string
+	accessLevel gitlab.AccessLevelValue
+	expiresAt   *gitlab.ISOTime
+}
+
+// getUserMapping returns how GitLab users are mapped to user names
+func (g *GitLabSyncer) getUserMapping() redhatcopv1beta1.GitLabUserMapping {
+	if g.Provider.UserMapping != "" {
+		return g.Provider.UserMapping
+	}
+
+	return redhatcopv1beta1.UsernameGitLabUserMapping
+}
+
+// getMembers returns the members of a group that are synchronized along with their user names. Members are omitted
+// based on their state and whether they are a bot, while members that cannot be mapped are handled according to the
+// unmapped user policy of the provider
+func (g *GitLabSyncer) getMembers(groupMembers []*gitlab.GroupMember) ([]gitLabMember, error) {
+	members := []gitLabMember{}
+	unmappedUsers := []string{}
+
+	for _, groupMember := range groupMembers {
+		if len(g.Provider.MemberStates) > 0 && !slices.Contains(g.Provider.MemberStates, groupMember.State) {
+			continue
+		}
+
+		if g.Provider.ExcludeBots {
+			user, err := g.getUser(groupMember.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			if user.Bot {
+				continue
+			}
+		}
+
+		name, mapped, err := g.mapUser(groupMember)
+		if err != nil {
+			return nil, err
+		}
+
+		if !mapped {
+			switch g.Provider.UnmappedUserPolicy {
+			case redhatcopv1beta1.RetainUnmappedUserPolicy:
+				name = groupMember.Username
+			case redhatcopv1beta1.FailUnmappedUserPolicy:
+				unmappedUsers = append(unmappedUsers, groupMember.Username)
+				continue
+			default:
+				gitlabLogger.Info("Skipping Unmapped User", "Username", groupMember.Username, "Mapping", g.getUserMapping(), "Provider", g.Name)
+				continue
+			}
+		}
+
+		members = append(members, gitLabMember{name: name, accessLevel: groupMember.AccessLevel, expiresAt: groupMember.ExpiresAt})
+	}
+
+	if len(unmappedUsers) > 0 {
+		return nil, fmt.Errorf("Unable to map GitLab users using %s: %s", g.getUserMapping(), strings.Join(unmappedUsers, ", "))
+	}
+
+	return members, nil
+}
+
+// mapUser returns the user name of a member and whether the member could be mapped. The identity mapping uses the
+// group SAML identity of the member when available and otherwise the identities of the user
+func (g *GitLabSyncer) mapUser(groupMember *gitlab.GroupMember) (string, bool, error) {
+	if g.getUserMapping() != redhatcopv1beta1.IdentityGitLabUserMapping {
+		return groupMember.Username, true, nil
+	}
+
+	if identity := groupMember.GroupSAMLIdentity; identity != nil && identity.ExternUID != "" && (g.Provider.IdentityProvider == "" || g.Provider.IdentityProvider == identity.Provider) {
+		return identity.ExternUID, true, nil
+	}
+
+	user, err := g.getUser(groupMember.ID)
+	if err != nil {
+		return "", false, err
+	}
+
+	for _, identity := range user.Identities {
+		if identity.ExternUID != "" && (g.Provider.IdentityProvider == "" || g.Provider.IdentityProvider == identity.Provider) {
+			return identity.ExternUID, true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+// getUser returns a user from the users API. Users are cached for the duration of a synchronization as the same user
+// is commonly a member of several groups
+func (g *GitLabSyncer) getUser(userID int) (*gitlab.User, error) {
+	if user, found := g.users[userID]; found {
+		return user, nil
+	}
+
+	user, _, err := g.Client.Users.GetUser(userID, gitlab.GetUsersOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if g.users == nil {
+		g.users = map[int]*gitlab.User{}
+	}
+	g.users[userID] = user
+
+	return user, nil
+}
@@ -0,0 +1,159 @@
+package syncer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	userv1 "github.com/openshift/api/user/v1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// groupHierarchy records the parent/child relationships between groups reported by a provider, keyed by group name
+type groupHierarchy map[string]sets.Set[string]
+
+// addChild records that child is nested within parent
+func (h groupHierarchy) addChild(parent, child string) {
+	if _, found := h[parent]; !found {
+		h[parent] = sets.New[string]()
+	}
+	h[parent].Insert(child)
+}
+
+// children returns the groups directly nested within a group
+func (h groupHierarchy) children(group string) []string {
+	return sets.List(h[group])
+}
+
+// parents returns the groups a group is directly nested within
+func (h groupHierarchy) parents(group string) []string {
+	parents := []string{}
+	for parent, children := range h {
+		if children.Has(group) {
+			parents = append(parents, parent)
+		}
+	}
+	sort.Strings(parents)
+
+	return parents
+}
+
+// apply annotates each group with its position in the hierarchy and, when requested, adds the members of nested
+// groups to each of their parents. An error is returned when the hierarchy contains a cycle
+func (h groupHierarchy) apply(groups []userv1.Group, hierarchy *redhatcopv1beta1.Hierarchy) error {
+
+	if err := h.validate(); err != nil {
+		return err
+	}
+
+	members := map[string][]string{}
+	for _, group := range groups {
+		members[group.Name] = append(members[group.Name], group.Users...)
+	}
+
+	for i := range groups {
+		group := &groups[i]
+
+		if group.Annotations == nil {
+			group.Annotations = map[string]string{}
+		}
+
+		if children := h.children(group.Name); len(children) > 0 {
+			group.Annotations[constants.HierarchyChildren] = strings.Join(children, ",")
+		}
+
+		parents := h.parents(group.Name)
+		if len(parents) == 1 {
+			group.Annotations[constants.HierarchyParent] = parents[0]
+		}
+		if len(parents) > 1 {
+			group.Annotations[constants.HierarchyParents] = strings.Join(parents, ",")
+		}
+
+		if hierarchy != nil && hierarchy.Flatten {
+			group.Users = h.flattenMembers(group.Name, members, hierarchy.MaxDepth)
+		}
+	}
+
+	return nil
+}
+
+// flattenMembers returns the members of a group along with the members of the groups nested within it.
+// Nested groups deeper than maxDepth levels are ignored unless maxDepth is 0
+func (h groupHierarchy) flattenMembers(group string, members map[string][]string, maxDepth int) []string {
+
+	users := []string{}
+	seenUsers := sets.New[string]()
+	seenGroups := sets.New(group)
+
+	level := []string{group}
+
+	for depth := 0; len(level) > 0 && (maxDepth == 0 || depth <= maxDepth); depth++ {
+		nextLevel := []string{}
+
+		for _, current := range level {
+			for _, user := range members[current] {
+				if !seenUsers.Has(user) {
+					seenUsers.Insert(user)
+					users = append(users, user)
+				}
+			}
+
+			for _, child := range h.children(current) {
+				if !seenGroups.Has(child) {
+					seenGroups.Insert(child)
+					nextLevel = append(nextLevel, child)
+				}
+			}
+		}
+
+		level = nextLevel
+	}
+
+	return users
+}
+
+// validate verifies the hierarchy does not contain a cycle
+func (h groupHierarchy) validate() error {
+
+	visited := sets.New[string]()
+
+	var visit func(group string, path []string) error
+	visit = func(group string, path []string) error {
+		for i, ancestor := range path {
+			if ancestor == group {
+				return fmt.Errorf("Group hierarchy contains a cycle: %s", strings.Join(append(path[i:], group), " -> "))
+			}
+		}
+
+		if visited.Has(group) {
+			return nil
+		}
+
+		for _, child := range h.children(group) {
+			if err := visit(child, append(append([]string{}, path...), group)); err != nil {
+				return err
+			}
+		}
+
+		visited.Insert(group)
+
+		return nil
+	}
+
+	parents := make([]string, 0, len(h))
+	for parent := range h {
+		parents = append(parents, parent)
+	}
+	sort.Strings(parents)
+
+	for _, parent := range parents {
+		if err := visit(parent, []string{}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
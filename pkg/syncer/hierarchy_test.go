@@ -0,0 +1,95 @@
+package syncer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	userv1 "github.com/openshift/api/user/v1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newHierarchyTestGroups() []userv1.Group {
+	return []userv1.Group{
+		{ObjectMeta: metav1.ObjectMeta{Name: "engineering"}, Users: []string{"jane"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "platform"}, Users: []string{"john", "jane"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "security"}, Users: []string{"joe"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "sre"}, Users: []string{"jim"}},
+	}
+}
+
+func newHierarchyTestEdges() groupHierarchy {
+	hierarchy := groupHierarchy{}
+	hierarchy.addChild("engineering", "platform")
+	hierarchy.addChild("engineering", "security")
+	hierarchy.addChild("platform", "sre")
+	hierarchy.addChild("security", "sre")
+
+	return hierarchy
+}
+
+func TestGroupHierarchy(t *testing.T) {
+	tests := []struct {
+		name      string
+		hierarchy *redhatcopv1beta1.Hierarchy
+		expected  map[string][]string
+	}{
+		{
+			name:     "annotations only",
+			expected: map[string][]string{"engineering": {"jane"}, "platform": {"john", "jane"}, "security": {"joe"}, "sre": {"jim"}},
+		},
+		{
+			name:      "flatten",
+			hierarchy: &redhatcopv1beta1.Hierarchy{Flatten: true},
+			expected:  map[string][]string{"engineering": {"jane", "john", "joe", "jim"}, "platform": {"john", "jane", "jim"}, "security": {"joe", "jim"}, "sre": {"jim"}},
+		},
+		{
+			name:      "flatten with maximum depth",
+			hierarchy: &redhatcopv1beta1.Hierarchy{Flatten: true, MaxDepth: 1},
+			expected:  map[string][]string{"engineering": {"jane", "john", "joe"}, "platform": {"john", "jane", "jim"}, "security": {"joe", "jim"}, "sre": {"jim"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups := newHierarchyTestGroups()
+
+			if err := newHierarchyTestEdges().apply(groups, tt.hierarchy); err != nil {
+				t.Fatalf("apply() error = %v", err)
+			}
+
+			actual := map[string][]string{}
+			for _, group := range groups {
+				actual[group.Name] = group.Users
+			}
+
+			if fmt.Sprint(actual) != fmt.Sprint(tt.expected) {
+				t.Errorf("apply() groups = %v, expected %v", actual, tt.expected)
+			}
+
+			if groups[0].Annotations[constants.HierarchyChildren] != "platform,security" {
+				t.Errorf("apply() children annotation = '%s'", groups[0].Annotations[constants.HierarchyChildren])
+			}
+
+			if groups[1].Annotations[constants.HierarchyParent] != "engineering" {
+				t.Errorf("apply() parent annotation = '%s'", groups[1].Annotations[constants.HierarchyParent])
+			}
+
+			if groups[3].Annotations[constants.HierarchyParents] != "platform,security" {
+				t.Errorf("apply() parents annotation = '%s'", groups[3].Annotations[constants.HierarchyParents])
+			}
+		})
+	}
+}
+
+func TestGroupHierarchyCycle(t *testing.T) {
+	hierarchy := newHierarchyTestEdges()
+	hierarchy.addChild("sre", "engineering")
+
+	err := hierarchy.apply(newHierarchyTestGroups(), &redhatcopv1beta1.Hierarchy{Flatten: true})
+	if err == nil || !strings.Contains(err.Error(), "engineering -> platform -> sre -> engineering") {
+		t.Errorf("apply() error = %v, expected a cycle to be detected", err)
+	}
+}
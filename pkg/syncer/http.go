@@ -0,0 +1,463 @@
+package syncer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	userv1 "github.com/openshift/api/user/v1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	httpLogger = logf.Log.WithName("syncer_http")
+)
+
+const (
+	defaultCursorParameter = "cursor"
+	defaultOffsetParameter = "offset"
+	defaultLimitParameter  = "limit"
+	defaultPaginationLimit = 100
+	maxPages               = 1000
+)
+
+type HTTPSyncer struct {
+	Name                string
+	GroupSync           *redhatcopv1beta1.GroupSync
+	Provider            *redhatcopv1beta1.HTTPProvider
+	Context             context.Context
+	ReconcilerBase      util.ReconcilerBase
+	CredentialsSecret   *corev1.Secret
+	URL                 *url.URL
+	Client              *http.Client
+	httpClientConfig    *httpClientConfig
+	groupFilter         *groupNameFilter
+	groupsExpression    expression
+	nameExpression      expression
+	uidExpression       expression
+	membersExpression   expression
+	cursorExpression    expression
+	attributeExpression map[string]expression
+}
+
+func (h *HTTPSyncer) Init() bool {
+
+	h.Context = context.Background()
+
+	changed := false
+
+	if h.Provider.Authentication == "" {
+		h.Provider.Authentication = redhatcopv1beta1.NoneHTTPAuthenticationType
+		changed = true
+	}
+
+	if h.Provider.Mapping.Language == "" {
+		h.Provider.Mapping.Language = redhatcopv1beta1.JSONPathExpressionLanguage
+		changed = true
+	}
+
+	return changed
+}
+
+func (h *HTTPSyncer) Validate() error {
+
+	validationErrors := []error{}
+
+	var err error
+	if h.URL, err = url.ParseRequestURI(h.Provider.URL); err != nil {
+		validationErrors = append(validationErrors, fmt.Errorf("Invalid URL: '%s'", h.Provider.URL))
+	}
+
+	if h.Provider.CredentialsSecret != nil {
+		credentialsSecret, err := getCredentialsSecret(h.Context, h.ReconcilerBase.GetClient(), h.GroupSync, h.Provider.CredentialsSecret)
+
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		} else {
+			h.CredentialsSecret = credentialsSecret
+		}
+	}
+
+	switch h.Provider.Authentication {
+	case redhatcopv1beta1.BearerHTTPAuthenticationType:
+		validationErrors = append(validationErrors, h.validateCredentials(secretTokenKey)...)
+	case redhatcopv1beta1.BasicHTTPAuthenticationType:
+		validationErrors = append(validationErrors, h.validateCredentials(secretUsernameKey, secretPasswordKey)...)
+	case redhatcopv1beta1.OAuth2HTTPAuthenticationType:
+		validationErrors = append(validationErrors, h.validateCredentials(secretClientIdKey, secretClientSecretKey)...)
+		if _, err := url.ParseRequestURI(h.Provider.TokenURL); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("Invalid token URL: '%s'", h.Provider.TokenURL))
+		}
+	}
+
+	httpClientConfig, err := newHTTPClientConfig(h.Context, h.ReconcilerBase.GetClient(), h.GroupSync, h.Provider.ProviderBase, h.Provider.HTTPClientOptions)
+	if err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+	h.httpClientConfig = httpClientConfig
+
+	groupFilter, err := newGroupNameFilter(h.Provider.Groups, h.Provider.GroupPatterns)
+	if err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+	h.groupFilter = groupFilter
+
+	validationErrors = append(validationErrors, h.compileExpressions()...)
+
+	return utilerrors.NewAggregate(validationErrors)
+}
+
+// validateCredentials verifies the credentials secret contains the keys required by the authentication method
+func (h *HTTPSyncer) validateCredentials(keys ...string) []error {
+
+	if h.Provider.CredentialsSecret == nil {
+		return []error{fmt.Errorf("credentialsSecret must be provided for the '%s' authentication method", h.Provider.Authentication)}
+	}
+
+	if h.CredentialsSecret == nil {
+		return nil
+	}
+
+	for _, key := range keys {
+		if _, found := h.CredentialsSecret.Data[key]; !found {
+			return []error{fmt.Errorf("Could not find `%s` key in secret '%s' in namespace '%s'", strings.Join(keys, "` and `"), h.Provider.CredentialsSecret.Name, h.Provider.CredentialsSecret.Namespace)}
+		}
+	}
+
+	return nil
+}
+
+// compileExpressions compiles the expressions used to extract groups from the response
+func (h *HTTPSyncer) compileExpressions() []error {
+
+	validationErrors := []error{}
+	language := h.Provider.Mapping.Language
+
+	compile := func(variable string, source string, required bool, description string) expression {
+		if source == "" {
+			if required {
+				validationErrors = append(validationErrors, fmt.Errorf("%s expression not provided", description))
+			}
+			return nil
+		}
+
+		compiled, err := compileExpression(language, variable, source)
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+		return compiled
+	}
+
+	h.groupsExpression = compile("response", h.Provider.Mapping.Groups, true, "Groups")
+	h.nameExpression = compile("group", h.Provider.Mapping.Name, true, "Name")
+	h.uidExpression = compile("group", h.Provider.Mapping.UID, false, "UID")
+	h.membersExpression = compile("group", h.Provider.Mapping.Members, false, "Members")
+
+	h.attributeExpression = map[string]expression{}
+	for annotation, source := range h.Provider.Mapping.Attributes {
+		if compiled := compile("group", source, true, fmt.Sprintf("Attribute '%s'", annotation)); compiled != nil {
+			h.attributeExpression[annotation] = compiled
+		}
+	}
+
+	if h.Provider.Pagination != nil && h.Provider.Pagination.Type == redhatcopv1beta1.CursorHTTPPaginationType {
+		h.cursorExpression = compile("response", h.Provider.Pagination.Cursor, true, "Cursor")
+	}
+
+	return validationErrors
+}
+
+func (h *HTTPSyncer) Bind() error {
+
+	h.Client = h.httpClientConfig.client()
+
+	if h.Provider.Authentication == redhatcopv1beta1.OAuth2HTTPAuthenticationType {
+		config := clientcredentials.Config{
+			ClientID:     string(h.CredentialsSecret.Data[secretClientIdKey]),
+			ClientSecret: string(h.CredentialsSecret.Data[secretClientSecretKey]),
+			TokenURL:     h.Provider.TokenURL,
+			Scopes:       h.Provider.Scopes,
+		}
+
+		h.Client = config.Client(context.WithValue(h.Context, oauth2.HTTPClient, h.Client))
+	}
+
+	return nil
+}
+
+func (h *HTTPSyncer) Sync() ([]userv1.Group, error) {
+
+	ocpGroups := []userv1.Group{}
+
+	pageURL := h.URL
+	offset := 0
+
+	for page := 0; pageURL != nil; page++ {
+
+		if page == maxPages {
+			return nil, fmt.Errorf("Exceeded the maximum of %d pages", maxPages)
+		}
+
+		if h.Provider.Pagination != nil && h.Provider.Pagination.Type == redhatcopv1beta1.OffsetHTTPPaginationType {
+			pageURL = h.offsetURL(offset)
+		}
+
+		response, header, err := h.fetch(pageURL)
+		if err != nil {
+			return nil, err
+		}
+
+		groups, err := h.groupsExpression.evaluate(response)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to evaluate groups expression: %w", err)
+		}
+
+		for _, group := range groups {
+			ocpGroup, err := h.toOpenShiftGroup(group)
+			if err != nil {
+				return nil, err
+			}
+
+			if !h.groupFilter.allows(ocpGroup.Name) {
+				continue
+			}
+
+			ocpGroups = append(ocpGroups, *ocpGroup)
+		}
+
+		if pageURL, err = h.nextURL(pageURL, response, header, len(groups)); err != nil {
+			return nil, err
+		}
+		offset += len(groups)
+	}
+
+	httpLogger.Info("Retrieved groups", "Provider", h.Name, "Groups", len(ocpGroups))
+
+	return ocpGroups, nil
+}
+
+// fetch requests a page from the endpoint, returning the decoded response and its headers
+func (h *HTTPSyncer) fetch(pageURL *url.URL) (interface{}, http.Header, error) {
+
+	request, err := http.NewRequestWithContext(h.Context, http.MethodGet, pageURL.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("User-Agent", userAgent)
+
+	switch h.Provider.Authentication {
+	case redhatcopv1beta1.BearerHTTPAuthenticationType:
+		request.Header.Set("Authorization", "Bearer "+string(h.CredentialsSecret.Data[secretTokenKey]))
+	case redhatcopv1beta1.BasicHTTPAuthenticationType:
+		request.SetBasicAuth(string(h.CredentialsSecret.Data[secretUsernameKey]), string(h.CredentialsSecret.Data[secretPasswordKey]))
+	}
+
+	response, err := h.Client.Do(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return nil, nil, fmt.Errorf("Request to '%s' failed with status %d: %s", pageURL.Redacted(), response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var data interface{}
+	if err := json.NewDecoder(response.Body).Decode(&data); err != nil {
+		return nil, nil, fmt.Errorf("Invalid JSON response from '%s': %w", pageURL.Redacted(), err)
+	}
+
+	return data, response.Header, nil
+}
+
+// offsetURL returns the location of the page starting at the given offset
+func (h *HTTPSyncer) offsetURL(offset int) *url.URL {
+
+	parameter := h.Provider.Pagination.Parameter
+	if parameter == "" {
+		parameter = defaultOffsetParameter
+	}
+
+	limitParameter := h.Provider.Pagination.LimitParameter
+	if limitParameter == "" {
+		limitParameter = defaultLimitParameter
+	}
+
+	pageURL := *h.URL
+	query := pageURL.Query()
+	query.Set(parameter, strconv.Itoa(offset))
+	query.Set(limitParameter, strconv.Itoa(h.paginationLimit()))
+	pageURL.RawQuery = query.Encode()
+
+	return &pageURL
+}
+
+func (h *HTTPSyncer) paginationLimit() int {
+	if h.Provider.Pagination.Limit > 0 {
+		return h.Provider.Pagination.Limit
+	}
+
+	return defaultPaginationLimit
+}
+
+// nextURL returns the location of the next page, or nil when all pages have been retrieved
+func (h *HTTPSyncer) nextURL(pageURL *url.URL, response interface{}, header http.Header, groupCount int) (*url.URL, error) {
+
+	if h.Provider.Pagination == nil {
+		return nil, nil
+	}
+
+	switch h.Provider.Pagination.Type {
+	case redhatcopv1beta1.LinkHTTPPaginationType:
+		next := parseNextLink(header)
+		if next == "" {
+			return nil, nil
+		}
+
+		nextURL, err := pageURL.Parse(next)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid next page link '%s': %w", next, err)
+		}
+
+		// Credentials are sent with every page, so pages are only requested from the origin of the provider URL
+		if nextURL.Scheme != h.URL.Scheme || !strings.EqualFold(nextURL.Host, h.URL.Host) {
+			return nil, fmt.Errorf("Next page link '%s' does not match the scheme and host of '%s'", next, h.URL.Redacted())
+		}
+		return nextURL, nil
+	case redhatcopv1beta1.CursorHTTPPaginationType:
+		cursor, err := evaluateString(h.cursorExpression, response)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to evaluate cursor expression: %w", err)
+		}
+
+		if cursor == "" {
+			return nil, nil
+		}
+
+		parameter := h.Provider.Pagination.Parameter
+		if parameter == "" {
+			parameter = defaultCursorParameter
+		}
+
+		nextURL := *h.URL
+		query := nextURL.Query()
+		query.Set(parameter, cursor)
+		nextURL.RawQuery = query.Encode()
+		return &nextURL, nil
+	case redhatcopv1beta1.OffsetHTTPPaginationType:
+		if groupCount < h.paginationLimit() {
+			return nil, nil
+		}
+		return pageURL, nil
+	}
+
+	return nil, nil
+}
+
+// parseNextLink returns the target of the "next" relation in the Link header
+func parseNextLink(header http.Header) string {
+
+	for _, link := range header.Values("Link") {
+		for _, part := range strings.Split(link, ",") {
+			segments := strings.Split(part, ";")
+			target := strings.TrimSpace(segments[0])
+
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+
+			for _, segment := range segments[1:] {
+				segment = strings.ReplaceAll(strings.TrimSpace(segment), " ", "")
+				if segment == `rel="next"` || segment == "rel=next" {
+					return strings.Trim(target, "<>")
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// toOpenShiftGroup maps a group from the response to an OpenShift group
+func (h *HTTPSyncer) toOpenShiftGroup(group interface{}) (*userv1.Group, error) {
+
+	name, err := evaluateString(h.nameExpression, group)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to evaluate name expression: %w", err)
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("Name expression returned no value for group: %v", group)
+	}
+
+	ocpGroup := &userv1.Group{
+		TypeMeta: v1.TypeMeta{
+			Kind:       "Group",
+			APIVersion: userv1.GroupVersion.String(),
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{},
+			Labels:      map[string]string{},
+		},
+		Users: []string{},
+	}
+
+	ocpGroup.GetAnnotations()[constants.SyncSourceHost] = h.URL.Host
+
+	if h.uidExpression != nil {
+		uid, err := evaluateString(h.uidExpression, group)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to evaluate uid expression for group '%s': %w", name, err)
+		}
+
+		if uid != "" {
+			ocpGroup.GetAnnotations()[constants.SyncSourceUID] = uid
+		}
+	}
+
+	if h.membersExpression != nil {
+		members, err := evaluateStrings(h.membersExpression, group)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to evaluate members expression for group '%s': %w", name, err)
+		}
+
+		ocpGroup.Users = append(ocpGroup.Users, members...)
+	}
+
+	for annotation, attributeExpression := range h.attributeExpression {
+		value, err := evaluateString(attributeExpression, group)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to evaluate attribute '%s' for group '%s': %w", annotation, name, err)
+		}
+
+		if value != "" {
+			ocpGroup.GetAnnotations()[annotation] = value
+		}
+	}
+
+	return ocpGroup, nil
+}
+
+func (h *HTTPSyncer) GetProviderName() string {
+	return h.Name
+}
+
+func (h *HTTPSyncer) GetPrune() bool {
+	return h.Provider.Prune
+}
@@ -0,0 +1,296 @@
+package syncer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var httpTestGroups = []map[string]interface{}{
+	{"id": 1, "displayName": "admins", "members": []map[string]interface{}{{"login": "jane"}, {"login": "john"}}, "costCenter": "1234"},
+	{"id": 2, "displayName": "developers", "members": []map[string]interface{}{{"login": "jim"}}},
+	{"id": 3, "displayName": "operators", "members": []map[string]interface{}{}},
+}
+
+// newHTTPTestServer serves httpTestGroups using the pagination style requested by the tests
+func newHTTPTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if clientId, clientSecret, ok := r.BasicAuth(); !ok || clientId != "client" || clientSecret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"oauth-token","token_type":"bearer","expires_in":3600}`)
+	})
+
+	mux.HandleFunc("/cursor", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer oauth-token" && r.Header.Get("Authorization") != "Bearer static-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		index, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+		response := map[string]interface{}{"data": httpTestGroups[index : index+1]}
+		if index+1 < len(httpTestGroups) {
+			response["next"] = strconv.Itoa(index + 1)
+		}
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mux.HandleFunc("/link", func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		index, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if index+1 < len(httpTestGroups) {
+			w.Header().Set("Link", fmt.Sprintf(`</link?page=%d>; rel="next", </link?page=0>; rel="first"`, index+1))
+		}
+		json.NewEncoder(w).Encode(httpTestGroups[index : index+1])
+	})
+
+	mux.HandleFunc("/offset", func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		end := offset + limit
+		if end > len(httpTestGroups) {
+			end = len(httpTestGroups)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"items": httpTestGroups[offset:end]})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestHTTPSyncer(t *testing.T) {
+	server := newHTTPTestServer(t)
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("unable to build scheme: %v", err)
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: testNamespace},
+			Data: map[string][]byte{
+				secretTokenKey:        []byte("static-token"),
+				secretUsernameKey:     []byte("user"),
+				secretPasswordKey:     []byte("password"),
+				secretClientIdKey:     []byte("client"),
+				secretClientSecretKey: []byte("secret"),
+			},
+		},
+	).Build()
+	reconcilerBase := util.NewReconcilerBase(k8sClient, scheme, nil, nil, k8sClient)
+
+	credentialsSecret := &redhatcopv1beta1.ObjectRef{Name: "credentials", Namespace: testNamespace}
+	jsonPathMapping := redhatcopv1beta1.HTTPMapping{
+		Groups:     "{.data}",
+		Name:       "{.displayName}",
+		UID:        "{.id}",
+		Members:    "{.members[*].login}",
+		Attributes: map[string]string{"example.com/cost-center": "{.costCenter}"},
+	}
+
+	tests := []struct {
+		name     string
+		provider redhatcopv1beta1.HTTPProvider
+		expected map[string][]string
+	}{
+		{
+			name: "cursor pagination with bearer authentication",
+			provider: redhatcopv1beta1.HTTPProvider{
+				ProviderBase:   redhatcopv1beta1.ProviderBase{CredentialsSecret: credentialsSecret},
+				URL:            server.URL + "/cursor",
+				Authentication: redhatcopv1beta1.BearerHTTPAuthenticationType,
+				Pagination:     &redhatcopv1beta1.HTTPPagination{Type: redhatcopv1beta1.CursorHTTPPaginationType, Cursor: "{.next}"},
+				Mapping:        jsonPathMapping,
+			},
+			expected: map[string][]string{"admins": {"jane", "john"}, "developers": {"jim"}, "operators": {}},
+		},
+		{
+			name: "cursor pagination with oauth2 authentication",
+			provider: redhatcopv1beta1.HTTPProvider{
+				ProviderBase:   redhatcopv1beta1.ProviderBase{CredentialsSecret: credentialsSecret},
+				GroupFilter:    redhatcopv1beta1.GroupFilter{Groups: []string{"developers"}},
+				URL:            server.URL + "/cursor",
+				Authentication: redhatcopv1beta1.OAuth2HTTPAuthenticationType,
+				TokenURL:       server.URL + "/token",
+				Pagination:     &redhatcopv1beta1.HTTPPagination{Type: redhatcopv1beta1.CursorHTTPPaginationType, Cursor: "{.next}"},
+				Mapping:        jsonPathMapping,
+			},
+			expected: map[string][]string{"developers": {"jim"}},
+		},
+		{
+			name: "link pagination with basic authentication and CEL mapping",
+			provider: redhatcopv1beta1.HTTPProvider{
+				ProviderBase:   redhatcopv1beta1.ProviderBase{CredentialsSecret: credentialsSecret},
+				URL:            server.URL + "/link",
+				Authentication: redhatcopv1beta1.BasicHTTPAuthenticationType,
+				Pagination:     &redhatcopv1beta1.HTTPPagination{Type: redhatcopv1beta1.LinkHTTPPaginationType},
+				Mapping: redhatcopv1beta1.HTTPMapping{
+					Language: redhatcopv1beta1.CELExpressionLanguage,
+					Groups:   "response.filter(g, size(g.members) > 0)",
+					Name:     `"idp-" + group.displayName`,
+					UID:      "group.id",
+					Members:  "group.members.map(m, m.login)",
+				},
+			},
+			expected: map[string][]string{"idp-admins": {"jane", "john"}, "idp-developers": {"jim"}},
+		},
+		{
+			name: "offset pagination",
+			provider: redhatcopv1beta1.HTTPProvider{
+				URL:        server.URL + "/offset",
+				Pagination: &redhatcopv1beta1.HTTPPagination{Type: redhatcopv1beta1.OffsetHTTPPaginationType, Parameter: "start", Limit: 2},
+				Mapping: redhatcopv1beta1.HTTPMapping{
+					Groups: "{.items[*]}",
+					Name:   "{.displayName}",
+				},
+			},
+			expected: map[string][]string{"admins": {}, "developers": {}, "operators": {}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpSyncer := &HTTPSyncer{
+				Name:           "rest",
+				GroupSync:      &redhatcopv1beta1.GroupSync{ObjectMeta: metav1.ObjectMeta{Name: "groupsync", Namespace: testNamespace}},
+				Provider:       tt.provider.DeepCopy(),
+				ReconcilerBase: reconcilerBase,
+			}
+
+			httpSyncer.Init()
+
+			if err := httpSyncer.Validate(); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+
+			if err := httpSyncer.Bind(); err != nil {
+				t.Fatalf("Bind() error = %v", err)
+			}
+
+			groups, err := httpSyncer.Sync()
+			if err != nil {
+				t.Fatalf("Sync() error = %v", err)
+			}
+
+			actual := map[string][]string{}
+			for _, group := range groups {
+				actual[group.Name] = group.Users
+			}
+
+			if fmt.Sprint(actual) != fmt.Sprint(tt.expected) {
+				t.Errorf("Sync() groups = %v, expected %v", actual, tt.expected)
+			}
+
+			if tt.provider.Mapping.UID != "" && groups[0].Annotations[constants.SyncSourceUID] == "" {
+				t.Errorf("Sync() did not record the source uid of group '%s'", groups[0].Name)
+			}
+		})
+	}
+}
+
+func TestHTTPSyncerValidation(t *testing.T) {
+	httpSyncer := &HTTPSyncer{
+		Name:      "rest",
+		GroupSync: &redhatcopv1beta1.GroupSync{ObjectMeta: metav1.ObjectMeta{Name: "groupsync", Namespace: testNamespace}},
+		Provider: &redhatcopv1beta1.HTTPProvider{
+			URL:            "https://groups.example.com",
+			Authentication: redhatcopv1beta1.BearerHTTPAuthenticationType,
+			Pagination:     &redhatcopv1beta1.HTTPPagination{Type: redhatcopv1beta1.CursorHTTPPaginationType},
+			Mapping: redhatcopv1beta1.HTTPMapping{
+				Groups: "{.items[",
+			},
+		},
+		ReconcilerBase: util.NewReconcilerBase(fake.NewClientBuilder().Build(), nil, nil, nil, nil),
+	}
+
+	httpSyncer.Init()
+
+	err := httpSyncer.Validate()
+	if err == nil {
+		t.Fatalf("Validate() expected an error")
+	}
+
+	for _, expected := range []string{"credentialsSecret must be provided", "Invalid JSONPath expression", "Name expression not provided", "Cursor expression not provided"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Validate() error = %v, expected to contain '%s'", err, expected)
+		}
+	}
+}
+
+func TestHTTPSyncerLinkPaginationOrigin(t *testing.T) {
+	// The server receiving a foreign next page link must never be sent the credentials of the provider
+	foreignRequests := 0
+	foreignServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		foreignRequests++
+		json.NewEncoder(w).Encode(httpTestGroups[1:])
+	}))
+	t.Cleanup(foreignServer.Close)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<%s/groups?page=1>; rel="next"`, foreignServer.URL))
+		json.NewEncoder(w).Encode(httpTestGroups[:1])
+	}))
+	t.Cleanup(server.Close)
+
+	k8sClient := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: testNamespace},
+			Data:       map[string][]byte{secretTokenKey: []byte("static-token")},
+		},
+	).Build()
+
+	httpSyncer := &HTTPSyncer{
+		Name:      "rest",
+		GroupSync: &redhatcopv1beta1.GroupSync{ObjectMeta: metav1.ObjectMeta{Name: "groupsync", Namespace: testNamespace}},
+		Provider: &redhatcopv1beta1.HTTPProvider{
+			ProviderBase:   redhatcopv1beta1.ProviderBase{CredentialsSecret: &redhatcopv1beta1.ObjectRef{Name: "credentials", Namespace: testNamespace}},
+			URL:            server.URL + "/groups",
+			Authentication: redhatcopv1beta1.BearerHTTPAuthenticationType,
+			Pagination:     &redhatcopv1beta1.HTTPPagination{Type: redhatcopv1beta1.LinkHTTPPaginationType},
+			Mapping:        redhatcopv1beta1.HTTPMapping{Groups: "{[*]}", Name: "{.displayName}"},
+		},
+		ReconcilerBase: util.NewReconcilerBase(k8sClient, clientgoscheme.Scheme, nil, nil, k8sClient),
+	}
+
+	httpSyncer.Init()
+
+	if err := httpSyncer.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	if err := httpSyncer.Bind(); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+
+	if _, err := httpSyncer.Sync(); err == nil || !strings.Contains(err.Error(), "does not match the scheme and host") {
+		t.Errorf("Sync() error = %v, expected the next page link to be rejected", err)
+	}
+
+	if foreignRequests != 0 {
+		t.Errorf("Sync() requested %d pages from a foreign host", foreignRequests)
+	}
+}
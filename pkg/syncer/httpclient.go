@@ -0,0 +1,194 @@
+package syncer
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	"golang.org/x/net/http/httpproxy"
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	tlsVersions = map[string]uint16{
+		"1.0": tls.VersionTLS10,
+		"1.1": tls.VersionTLS11,
+		"1.2": tls.VersionTLS12,
+		"1.3": tls.VersionTLS13,
+	}
+)
+
+// httpClientConfig represents the connection settings used to build the HTTP client for a provider
+type httpClientConfig struct {
+	caCertificate     []byte
+	clientCertificate *tls.Certificate
+	insecure          bool
+	minTLSVersion     uint16
+	proxy             func(*http.Request) (*url.URL, error)
+	timeout           time.Duration
+}
+
+// newHTTPClientConfig loads the certificates and connection settings referenced by a provider.
+// A usable configuration is always returned alongside any errors encountered.
+func newHTTPClientConfig(context context.Context, client client.Client, groupSync *redhatcopv1beta1.GroupSync, providerBase redhatcopv1beta1.ProviderBase, options redhatcopv1beta1.HTTPClientOptions) (*httpClientConfig, error) {
+
+	validationErrors := []error{}
+
+	config := &httpClientConfig{
+		insecure: providerBase.Insecure,
+	}
+
+	if providerBase.Ca != nil {
+		caCertificate, err := getCaCertificate(context, client, groupSync, providerBase.Ca)
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+		config.caCertificate = caCertificate
+	}
+
+	if options.ClientCertificate != nil {
+		clientCertificate, err := getClientCertificate(context, client, groupSync, options.ClientCertificate)
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+		config.clientCertificate = clientCertificate
+	}
+
+	if options.MinTLSVersion != "" {
+		minTLSVersion, found := tlsVersions[options.MinTLSVersion]
+		if !found {
+			validationErrors = append(validationErrors, fmt.Errorf("Unsupported minimum TLS version '%s'", options.MinTLSVersion))
+		}
+		config.minTLSVersion = minTLSVersion
+	}
+
+	if options.Proxy != nil {
+		proxy, err := getProxy(context, client, groupSync, options.Proxy)
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+		config.proxy = proxy
+	}
+
+	if options.Timeout != nil {
+		if options.Timeout.Duration < 0 {
+			validationErrors = append(validationErrors, fmt.Errorf("Timeout must not be negative"))
+		} else {
+			config.timeout = options.Timeout.Duration
+		}
+	}
+
+	return config, utilerrors.NewAggregate(validationErrors)
+}
+
+// getClientCertificate returns the client certificate and key contained in the referenced Secret
+func getClientCertificate(context context.Context, client client.Client, groupSync *redhatcopv1beta1.GroupSync, resource *redhatcopv1beta1.ObjectRef) (*tls.Certificate, error) {
+
+	if resource.Kind != "" && resource.Kind != redhatcopv1beta1.SecretMapObjectRefKind {
+		return nil, fmt.Errorf("Client certificate must be provided in a Secret")
+	}
+
+	secret, err := getCredentialsSecret(context, client, groupSync, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	certificate, certificateFound := secret.Data[corev1.TLSCertKey]
+	privateKey, privateKeyFound := secret.Data[corev1.TLSPrivateKeyKey]
+
+	if !certificateFound || !privateKeyFound {
+		return nil, fmt.Errorf("Could not find '%s' and '%s' keys in secret '%s' in namespace '%s'", corev1.TLSCertKey, corev1.TLSPrivateKeyKey, resource.Name, resource.Namespace)
+	}
+
+	clientCertificate, err := tls.X509KeyPair(certificate, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid client certificate in secret '%s' in namespace '%s': %w", resource.Name, resource.Namespace, err)
+	}
+
+	return &clientCertificate, nil
+}
+
+// getProxy returns the function selecting the proxy for each request made to the provider
+func getProxy(context context.Context, client client.Client, groupSync *redhatcopv1beta1.GroupSync, proxy *redhatcopv1beta1.Proxy) (func(*http.Request) (*url.URL, error), error) {
+
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil || proxyURL.Host == "" {
+		return nil, fmt.Errorf("Invalid proxy URL: '%s'", proxy.URL)
+	}
+
+	if proxy.CredentialsSecret != nil {
+		credentialsSecret, err := getCredentialsSecret(context, client, groupSync, proxy.CredentialsSecret)
+		if err != nil {
+			return nil, err
+		}
+
+		username, usernameFound := credentialsSecret.Data[secretUsernameKey]
+		password, passwordFound := credentialsSecret.Data[secretPasswordKey]
+
+		if !usernameFound || !passwordFound {
+			return nil, fmt.Errorf("Could not find '%s' and '%s' keys in secret '%s' in namespace '%s'", secretUsernameKey, secretPasswordKey, proxy.CredentialsSecret.Name, proxy.CredentialsSecret.Namespace)
+		}
+
+		proxyURL.User = url.UserPassword(string(username), string(password))
+	}
+
+	proxyConfig := &httpproxy.Config{
+		HTTPProxy:  proxyURL.String(),
+		HTTPSProxy: proxyURL.String(),
+		NoProxy:    strings.Join(proxy.NoProxy, ","),
+	}
+	proxyFunc := proxyConfig.ProxyFunc()
+
+	return func(request *http.Request) (*url.URL, error) {
+		return proxyFunc(request.URL)
+	}, nil
+}
+
+// tlsConfig returns the TLS configuration used to communicate with the provider
+func (h *httpClientConfig) tlsConfig() *tls.Config {
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: h.insecure,
+		MinVersion:         h.minTLSVersion,
+	}
+
+	if len(h.caCertificate) > 0 {
+		tlsConfig.RootCAs = x509.NewCertPool()
+		tlsConfig.RootCAs.AppendCertsFromPEM(h.caCertificate)
+	}
+
+	if h.clientCertificate != nil {
+		tlsConfig.Certificates = []tls.Certificate{*h.clientCertificate}
+	}
+
+	return tlsConfig
+}
+
+// transport returns a new transport based on the default transport using the provider TLS and proxy configuration
+func (h *httpClientConfig) transport() *http.Transport {
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = h.tlsConfig()
+
+	if h.proxy != nil {
+		transport.Proxy = h.proxy
+	}
+
+	return transport
+}
+
+// client returns a new HTTP client used to communicate with the provider
+func (h *httpClientConfig) client() *http.Client {
+	return &http.Client{
+		Transport: h.transport(),
+		Timeout:   h.timeout,
+	}
+}
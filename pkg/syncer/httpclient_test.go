@@ -0,0 +1,215 @@
+package syncer
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testNamespace = "group-sync-operator"
+
+// newTestServer returns a TLS server requiring a client certificate along with the PEM encoded server CA and a client certificate and key
+func newTestServer(t *testing.T) (*httptest.Server, []byte, []byte, []byte) {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	serverCa := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	// The httptest server certificate doubles as the client certificate
+	clientCertificate := server.TLS.Certificates[0]
+	clientKey, err := x509.MarshalPKCS8PrivateKey(clientCertificate.PrivateKey)
+	if err != nil {
+		t.Fatalf("unable to marshal client key: %v", err)
+	}
+
+	return server,
+		serverCa,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: clientCertificate.Certificate[0]}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: clientKey})
+}
+
+func TestNewHTTPClientConfig(t *testing.T) {
+	server, serverCa, clientCertificate, clientKey := newTestServer(t)
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("unable to build scheme: %v", err)
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "provider-ca", Namespace: testNamespace},
+			Data:       map[string][]byte{defaultResourceCaKey: serverCa},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "client-certificate", Namespace: testNamespace},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{corev1.TLSCertKey: clientCertificate, corev1.TLSPrivateKeyKey: clientKey},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "missing-key", Namespace: testNamespace},
+			Data:       map[string][]byte{corev1.TLSCertKey: clientCertificate},
+		},
+	).Build()
+
+	groupSync := &redhatcopv1beta1.GroupSync{ObjectMeta: metav1.ObjectMeta{Name: "groupsync", Namespace: testNamespace}}
+	providerBase := redhatcopv1beta1.ProviderBase{
+		Ca: &redhatcopv1beta1.ObjectRef{Name: "provider-ca", Namespace: testNamespace, Kind: redhatcopv1beta1.SecretMapObjectRefKind},
+	}
+
+	tests := []struct {
+		name          string
+		options       redhatcopv1beta1.HTTPClientOptions
+		expectError   bool
+		expectRequest bool
+	}{
+		{
+			name:          "without client certificate",
+			options:       redhatcopv1beta1.HTTPClientOptions{},
+			expectRequest: false,
+		},
+		{
+			name: "with client certificate",
+			options: redhatcopv1beta1.HTTPClientOptions{
+				ClientCertificate: &redhatcopv1beta1.ObjectRef{Name: "client-certificate", Namespace: testNamespace},
+				MinTLSVersion:     "1.2",
+				Timeout:           &metav1.Duration{Duration: 5 * time.Second},
+			},
+			expectRequest: true,
+		},
+		{
+			name: "client certificate missing private key",
+			options: redhatcopv1beta1.HTTPClientOptions{
+				ClientCertificate: &redhatcopv1beta1.ObjectRef{Name: "missing-key", Namespace: testNamespace},
+			},
+			expectError: true,
+		},
+		{
+			name: "client certificate in a configmap",
+			options: redhatcopv1beta1.HTTPClientOptions{
+				ClientCertificate: &redhatcopv1beta1.ObjectRef{Name: "client-certificate", Namespace: testNamespace, Kind: redhatcopv1beta1.ConfigMapObjectRefKind},
+			},
+			expectError: true,
+		},
+		{
+			name: "unsupported minimum TLS version",
+			options: redhatcopv1beta1.HTTPClientOptions{
+				MinTLSVersion: "2.0",
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := newHTTPClientConfig(context.TODO(), k8sClient, groupSync, providerBase, tt.options)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("newHTTPClientConfig() error = %v, expectError %v", err, tt.expectError)
+			}
+
+			if config == nil {
+				t.Fatalf("newHTTPClientConfig() returned a nil configuration")
+			}
+
+			if tt.expectError {
+				return
+			}
+
+			if tt.options.Timeout != nil && config.client().Timeout != tt.options.Timeout.Duration {
+				t.Errorf("client() timeout = %v, expected %v", config.client().Timeout, tt.options.Timeout.Duration)
+			}
+
+			response, err := config.client().Get(server.URL)
+			if response != nil {
+				response.Body.Close()
+			}
+
+			if (err == nil) != tt.expectRequest {
+				t.Errorf("request error = %v, expectRequest %v", err, tt.expectRequest)
+			}
+		})
+	}
+}
+
+func TestHTTPClientConfigProxy(t *testing.T) {
+	proxiedRequests := 0
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := parseProxyAuthorization(r); !ok || username != "proxy-user" || password != "proxy-password" {
+			w.WriteHeader(http.StatusProxyAuthRequired)
+			return
+		}
+		proxiedRequests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(proxy.Close)
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("unable to build scheme: %v", err)
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "proxy-credentials", Namespace: testNamespace},
+			Data:       map[string][]byte{secretUsernameKey: []byte("proxy-user"), secretPasswordKey: []byte("proxy-password")},
+		},
+	).Build()
+
+	groupSync := &redhatcopv1beta1.GroupSync{ObjectMeta: metav1.ObjectMeta{Name: "groupsync", Namespace: testNamespace}}
+	options := redhatcopv1beta1.HTTPClientOptions{
+		Proxy: &redhatcopv1beta1.Proxy{
+			URL:               proxy.URL,
+			CredentialsSecret: &redhatcopv1beta1.ObjectRef{Name: "proxy-credentials", Namespace: testNamespace},
+			NoProxy:           []string{".direct.example.com"},
+		},
+	}
+
+	config, err := newHTTPClientConfig(context.TODO(), k8sClient, groupSync, redhatcopv1beta1.ProviderBase{}, options)
+	if err != nil {
+		t.Fatalf("newHTTPClientConfig() error = %v", err)
+	}
+
+	response, err := config.client().Get("http://idp.example.com/groups")
+	if err != nil {
+		t.Fatalf("proxied request error = %v", err)
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusOK || proxiedRequests != 1 {
+		t.Errorf("proxied request status = %d, proxied requests = %d", response.StatusCode, proxiedRequests)
+	}
+
+	proxyURL, err := config.proxy(httptest.NewRequest(http.MethodGet, "https://idp.direct.example.com/groups", nil))
+	if err != nil || proxyURL != nil {
+		t.Errorf("proxy() for excluded host = %v, %v, expected no proxy", proxyURL, err)
+	}
+
+	options.Proxy.URL = "not a url"
+	if _, err := newHTTPClientConfig(context.TODO(), k8sClient, groupSync, redhatcopv1beta1.ProviderBase{}, options); err == nil {
+		t.Errorf("newHTTPClientConfig() expected error for invalid proxy URL")
+	}
+}
+
+// parseProxyAuthorization returns the basic credentials provided in the Proxy-Authorization header
+func parseProxyAuthorization(r *http.Request) (string, string, bool) {
+	request := &http.Request{Header: http.Header{"Authorization": r.Header.Values("Proxy-Authorization")}}
+	return request.BasicAuth()
+}
@@ -8,13 +8,11 @@ import (
 
 	retryablehttp "github.com/hashicorp/go-retryablehttp"
 	userv1 "github.com/openshift/api/user/v1"
-	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
 	"github.com/redhat-cop/group-sync-operator/pkg/constants"
 	"github.com/redhat-cop/group-sync-operator/pkg/provider/ibmsecurityverify"
 	"github.com/redhat-cop/operator-utils/pkg/util"
-	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -24,12 +22,13 @@ var (
 )
 
 type IbmSecurityVerifySyncer struct {
-	Name           string
-	GroupSync      *redhatcopv1alpha1.GroupSync
-	Provider       *redhatcopv1alpha1.IbmSecurityVerifyProvider
-	Context        context.Context
-	ReconcilerBase util.ReconcilerBase
-	ApiClient      ibmsecurityverify.IbmSecurityVerifyClient
+	Name             string
+	GroupSync        *redhatcopv1beta1.GroupSync
+	Provider         *redhatcopv1beta1.IbmSecurityVerifyProvider
+	Context          context.Context
+	ReconcilerBase   util.ReconcilerBase
+	ApiClient        ibmsecurityverify.IbmSecurityVerifyClient
+	httpClientConfig *httpClientConfig
 }
 
 func (g *IbmSecurityVerifySyncer) Init() bool {
@@ -41,8 +40,7 @@ func (g *IbmSecurityVerifySyncer) Validate() error {
 	validationErrors := []error{}
 
 	if g.Provider.CredentialsSecret != nil {
-		credentialsSecret := &corev1.Secret{}
-		err := g.ReconcilerBase.GetClient().Get(g.Context, types.NamespacedName{Name: g.Provider.CredentialsSecret.Name, Namespace: g.Provider.CredentialsSecret.Namespace}, credentialsSecret)
+		credentialsSecret, err := getCredentialsSecret(g.Context, g.ReconcilerBase.GetClient(), g.GroupSync, g.Provider.CredentialsSecret)
 		if err != nil {
 			validationErrors = append(validationErrors, err)
 		} else {
@@ -60,6 +58,12 @@ func (g *IbmSecurityVerifySyncer) Validate() error {
 		validationErrors = append(validationErrors, fmt.Errorf("credentialsSecret must be provided for IBM Security Verify provider"))
 	}
 
+	httpClientConfig, err := newHTTPClientConfig(g.Context, g.ReconcilerBase.GetClient(), g.GroupSync, g.Provider.ProviderBase, g.Provider.HTTPClientOptions)
+	if err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+	g.httpClientConfig = httpClientConfig
+
 	if g.Provider.TenantURL == "" {
 		validationErrors = append(validationErrors, fmt.Errorf("tenant URL not provided"))
 	}
@@ -74,6 +78,7 @@ func (g *IbmSecurityVerifySyncer) Validate() error {
 func (g *IbmSecurityVerifySyncer) Bind() error {
 	retryClient := retryablehttp.NewClient()
 	retryClient.RetryMax = 10
+	retryClient.HTTPClient = g.httpClientConfig.client()
 	g.ApiClient.SetHttpClient(retryClient.StandardClient())
 	return nil
 }
@@ -113,7 +118,7 @@ func (g *IbmSecurityVerifySyncer) GetProviderName() string {
 }
 
 func (g *IbmSecurityVerifySyncer) GetPrune() bool {
-	return false
+	return g.Provider.Prune
 }
 
 func (g *IbmSecurityVerifySyncer) normalizeName(name string) string {
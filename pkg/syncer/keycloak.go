@@ -2,21 +2,18 @@ package syncer
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
 	"net/url"
 	"strings"
-
-	"crypto/x509"
+	"time"
 
 	"github.com/Nerzal/gocloak/v13"
 	userv1 "github.com/openshift/api/user/v1"
-	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
 	"github.com/redhat-cop/group-sync-operator/pkg/constants"
 	"github.com/redhat-cop/operator-utils/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/validation"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -34,8 +31,8 @@ var (
 
 type KeycloakSyncer struct {
 	Name               string
-	GroupSync          *redhatcopv1alpha1.GroupSync
-	Provider           *redhatcopv1alpha1.KeycloakProvider
+	GroupSync          *redhatcopv1beta1.GroupSync
+	Provider           *redhatcopv1beta1.KeycloakProvider
 	GoCloak            *gocloak.GoCloak
 	Context            context.Context
 	URL                *url.URL
@@ -44,7 +41,8 @@ type KeycloakSyncer struct {
 	CachedGroupMembers map[string][]*gocloak.User
 	ReconcilerBase     util.ReconcilerBase
 	CredentialsSecret  *corev1.Secret
-	CaCertificate      []byte
+	httpClientConfig   *httpClientConfig
+	groupFilter        *groupNameFilter
 }
 
 func (k *KeycloakSyncer) Init() bool {
@@ -62,7 +60,7 @@ func (k *KeycloakSyncer) Init() bool {
 	}
 
 	if k.Provider.Scope == "" {
-		k.Provider.Scope = redhatcopv1alpha1.SubSyncScope
+		k.Provider.Scope = redhatcopv1beta1.SubSyncScope
 		changed = true
 	}
 
@@ -76,8 +74,7 @@ func (k *KeycloakSyncer) Validate() error {
 
 	// Verify Secret Containing Username and Password Exists with Valid Keys
 	if k.Provider.CredentialsSecret != nil {
-		credentialsSecret := &corev1.Secret{}
-		err := k.ReconcilerBase.GetClient().Get(context.TODO(), types.NamespacedName{Name: k.Provider.CredentialsSecret.Name, Namespace: k.Provider.CredentialsSecret.Namespace}, credentialsSecret)
+		credentialsSecret, err := getCredentialsSecret(context.TODO(), k.ReconcilerBase.GetClient(), k.GroupSync, k.Provider.CredentialsSecret)
 
 		if err != nil {
 			validationErrors = append(validationErrors, err)
@@ -105,29 +102,17 @@ func (k *KeycloakSyncer) Validate() error {
 		validationErrors = append(validationErrors, err)
 	}
 
-	providerCaResource := determineFromDeprecatedObjectRef(k.Provider.Ca, k.Provider.CaSecret)
-	if providerCaResource != nil {
-
-		caResource, err := getObjectRefData(k.Context, k.ReconcilerBase.GetClient(), providerCaResource)
-
-		if err != nil {
-			validationErrors = append(validationErrors, err)
-		}
-
-		var resourceCaKey string
-		if providerCaResource.Key != "" {
-			resourceCaKey = providerCaResource.Key
-		} else {
-			resourceCaKey = defaultResourceCaKey
-		}
-
-		// Certificate key validation
-		if _, found := caResource[resourceCaKey]; !found {
-			validationErrors = append(validationErrors, fmt.Errorf("Could not find '%s' key in %s '%s' in namespace '%s'", resourceCaKey, providerCaResource.Kind, providerCaResource.Name, providerCaResource.Namespace))
-		}
+	httpClientConfig, err := newHTTPClientConfig(k.Context, k.ReconcilerBase.GetClient(), k.GroupSync, k.Provider.ProviderBase, k.Provider.HTTPClientOptions)
+	if err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+	k.httpClientConfig = httpClientConfig
 
-		k.CaCertificate = caResource[resourceCaKey]
+	groupFilter, err := newGroupNameFilter(k.Provider.Groups, k.Provider.GroupPatterns)
+	if err != nil {
+		validationErrors = append(validationErrors, err)
 	}
+	k.groupFilter = groupFilter
 
 	return utilerrors.NewAggregate(validationErrors)
 
@@ -138,21 +123,10 @@ func (k *KeycloakSyncer) Bind() error {
 	k.GoCloak = gocloak.NewClient(k.Provider.URL)
 	restyClient := k.GoCloak.RestyClient()
 
-	if k.Provider.Insecure {
-		restyClient.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true})
-	}
-
-	// Add trusted certificate if provided
-	if len(k.CaCertificate) > 0 {
-
-		tlsConfig := &tls.Config{}
-		if tlsConfig.RootCAs == nil {
-			tlsConfig.RootCAs = x509.NewCertPool()
-		}
+	restyClient.SetTransport(k.httpClientConfig.transport())
 
-		tlsConfig.RootCAs.AppendCertsFromPEM(k.CaCertificate)
-
-		restyClient.SetTLSClientConfig(tlsConfig)
+	if k.httpClientConfig.timeout > 0 {
+		restyClient.SetTimeout(k.httpClientConfig.timeout)
 	}
 
 	k.GoCloak.SetRestyClient(restyClient)
@@ -188,6 +162,7 @@ func (k *KeycloakSyncer) Sync() ([]userv1.Group, error) {
 	}
 
 	ocpGroups := []userv1.Group{}
+	hierarchy := groupHierarchy{}
 
 	for _, cachedGroup := range k.CachedGroups {
 
@@ -218,57 +193,66 @@ func (k *KeycloakSyncer) Sync() ([]userv1.Group, error) {
 			Users: []string{},
 		}
 
-		childrenGroups := []string{}
-
 		if cachedGroup.SubGroups != nil {
-			cachedGroupSubgroups := *cachedGroup.SubGroups
-
-			for _, subgroup := range cachedGroupSubgroups {
-
-				childrenGroups = append(childrenGroups, *subgroup.Name)
-			}
-		}
-
-		parentGroups := []string{}
-
-		for _, group := range k.CachedGroups {
-			if group.SubGroups != nil {
-				groupSubGroups := *group.SubGroups
-				for _, subgroup := range groupSubGroups {
-					if *subgroup.Name == *cachedGroup.Name {
-						parentGroups = append(parentGroups, *group.Name)
-					}
-				}
+			for _, subgroup := range *cachedGroup.SubGroups {
+				hierarchy.addChild(*cachedGroup.Name, *subgroup.Name)
 			}
 		}
 
 		// Set Host Specific Details
 		ocpGroup.GetAnnotations()[constants.SyncSourceHost] = k.URL.Host
 		ocpGroup.GetAnnotations()[constants.SyncSourceUID] = *cachedGroup.ID
-		if len(childrenGroups) > 0 {
-			ocpGroup.GetAnnotations()[constants.HierarchyChildren] = strings.Join(childrenGroups, ",")
-		}
-		if len(parentGroups) == 1 {
-			ocpGroup.GetAnnotations()[constants.HierarchyParent] = parentGroups[0]
-		}
-		if len(parentGroups) > 1 {
-			ocpGroup.GetAnnotations()[constants.HierarchyParents] = strings.Join(parentGroups, ",")
-		}
+
+		memberExpiry := map[string]time.Time{}
 
 		for _, user := range k.CachedGroupMembers[*cachedGroup.ID] {
 			ocpGroup.Users = append(ocpGroup.Users, *user.Username)
+
+			if expiry, found := k.getMembershipExpiry(user); found {
+				memberExpiry[*user.Username] = expiry
+			}
+		}
+
+		if err := setMemberExpiry(&ocpGroup, memberExpiry); err != nil {
+			return nil, err
 		}
 
 		ocpGroups = append(ocpGroups, ocpGroup)
 
 	}
 
+	// Members of subgroups are already added to their parents when using the sub scope
+	if err := hierarchy.apply(ocpGroups, nil); err != nil {
+		return nil, err
+	}
+
 	return ocpGroups, nil
 }
 
-func (k *KeycloakSyncer) processGroupsAndMembers(group, parentGroup *gocloak.Group, scope redhatcopv1alpha1.SyncScope) error {
+// getMembershipExpiry returns the time at which the group memberships of a user expire
+func (k *KeycloakSyncer) getMembershipExpiry(user *gocloak.User) (time.Time, bool) {
+
+	if k.Provider.MembershipExpiryAttribute == "" || user.Attributes == nil {
+		return time.Time{}, false
+	}
 
-	if parentGroup == nil && !isGroupAllowed(*group.Name, k.Provider.Groups) {
+	values := (*user.Attributes)[k.Provider.MembershipExpiryAttribute]
+	if len(values) == 0 {
+		return time.Time{}, false
+	}
+
+	expiry, err := time.Parse(time.RFC3339, values[0])
+	if err != nil {
+		keycloakLogger.Info("Ignoring invalid membership expiry", "Provider", k.Name, "User", *user.Username, "Attribute", k.Provider.MembershipExpiryAttribute, "Value", values[0])
+		return time.Time{}, false
+	}
+
+	return expiry, true
+}
+
+func (k *KeycloakSyncer) processGroupsAndMembers(group, parentGroup *gocloak.Group, scope redhatcopv1beta1.SyncScope) error {
+
+	if parentGroup == nil && !k.groupFilter.allows(*group.Name) {
 		return nil
 	}
 
@@ -289,7 +273,7 @@ func (k *KeycloakSyncer) processGroupsAndMembers(group, parentGroup *gocloak.Gro
 	}
 
 	// Process Subgroups
-	if redhatcopv1alpha1.SubSyncScope == scope && group.SubGroups != nil {
+	if redhatcopv1beta1.SubSyncScope == scope && group.SubGroups != nil {
 		groupSubGroups := *group.SubGroups
 		for _, subGroup := range groupSubGroups {
 			if _, subGroupFound := k.CachedGroups[*subGroup.ID]; !subGroupFound {
@@ -380,12 +364,15 @@ func (k *KeycloakSyncer) getGroups() ([]*gocloak.Group, error) {
 func (k *KeycloakSyncer) getGroupMembers(groupId string) ([]*gocloak.User, error) {
 	members := []*gocloak.User{}
 
+	// User attributes are only included in full representations
+	briefRepresentation := k.Provider.MembershipExpiryAttribute == ""
+
 	iteration := 0
 
 	for {
 
 		uIteration := iteration * iterationMax
-		groupMemberParams := gocloak.GetGroupsParams{First: &uIteration, Max: &iterationMax, BriefRepresentation: &truthy}
+		groupMemberParams := gocloak.GetGroupsParams{First: &uIteration, Max: &iterationMax, BriefRepresentation: &briefRepresentation}
 		groupMembers, err := k.GoCloak.GetGroupMembers(k.Context, k.Token.AccessToken, k.Provider.Realm, groupId, groupMemberParams)
 
 		if err != nil {
@@ -0,0 +1,94 @@
+package syncer
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Nerzal/gocloak/v13"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+)
+
+func TestKeycloakSyncMemberExpiry(t *testing.T) {
+	briefRepresentation := ""
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin/realms/ocp/groups", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("first") != "0" {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id": "1", "name": "developers"}]`)
+	})
+	mux.HandleFunc("/admin/realms/ocp/groups/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "1", "name": "developers"}`)
+	})
+	mux.HandleFunc("/admin/realms/ocp/groups/1/members", func(w http.ResponseWriter, r *http.Request) {
+		briefRepresentation = r.URL.Query().Get("briefRepresentation")
+		if r.URL.Query().Get("first") != "0" {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		fmt.Fprint(w, `[
+			{"id": "1", "username": "jane", "attributes": {"accessExpiry": ["2020-01-01T00:00:00Z"]}},
+			{"id": "2", "username": "john", "attributes": {"accessExpiry": ["2030-01-01T00:00:00Z"]}},
+			{"id": "3", "username": "jim"},
+			{"id": "4", "username": "joan", "attributes": {"accessExpiry": ["next week"]}}
+		]`)
+	})
+
+	// Responses are only decoded by the client when identified as JSON
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		mux.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	groupFilter, _ := newGroupNameFilter(nil, nil)
+
+	keycloakSyncer := &KeycloakSyncer{
+		Name:        "keycloak",
+		Provider:    &redhatcopv1beta1.KeycloakProvider{Realm: "ocp", MembershipExpiryAttribute: "accessExpiry"},
+		GoCloak:     gocloak.NewClient(server.URL),
+		Token:       &gocloak.JWT{AccessToken: "token"},
+		URL:         serverURL,
+		groupFilter: groupFilter,
+	}
+	keycloakSyncer.Init()
+
+	groups, err := keycloakSyncer.Sync()
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	// User attributes are only returned in full representations
+	if briefRepresentation != "false" {
+		t.Errorf("Sync() requested members using briefRepresentation = '%s', expected 'false'", briefRepresentation)
+	}
+
+	if len(groups) != 1 || fmt.Sprint(groups[0].Users) != "[jane john jim joan]" {
+		t.Fatalf("Sync() = %v, expected a single group containing every member", groups)
+	}
+
+	expectedExpiry := `{"jane":"2020-01-01T00:00:00Z","john":"2030-01-01T00:00:00Z"}`
+	if groups[0].Annotations[constants.SyncMemberExpiry] != expectedExpiry {
+		t.Errorf("Sync() member expiry = %s, expected %s", groups[0].Annotations[constants.SyncMemberExpiry], expectedExpiry)
+	}
+
+	nextExpiry, err := RemoveExpiredMembers(groups, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("RemoveExpiredMembers() error = %v", err)
+	}
+
+	if fmt.Sprint(groups[0].Users) != "[john jim joan]" {
+		t.Errorf("RemoveExpiredMembers() users = %v, expected the expired member to be removed", groups[0].Users)
+	}
+	if nextExpiry == nil || !nextExpiry.Equal(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("RemoveExpiredMembers() next expiry = %v", nextExpiry)
+	}
+}
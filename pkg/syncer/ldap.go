@@ -14,7 +14,7 @@ import (
 	userv1 "github.com/openshift/api/user/v1"
 	"github.com/openshift/library-go/pkg/security/ldapclient"
 	"github.com/openshift/library-go/pkg/security/ldaputil"
-	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
 	"github.com/redhat-cop/group-sync-operator/pkg/provider/ldap/builders"
 	ldapbuilders "github.com/redhat-cop/group-sync-operator/pkg/provider/ldap/builders"
 	syncgroups "github.com/redhat-cop/group-sync-operator/pkg/provider/ldap/helpers"
@@ -22,7 +22,6 @@ import (
 	syncerror "github.com/redhat-cop/group-sync-operator/pkg/provider/ldap/helpers/syncerror"
 	"github.com/redhat-cop/operator-utils/pkg/util"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -34,8 +33,8 @@ var (
 
 type LdapSyncer struct {
 	Name              string
-	GroupSync         *redhatcopv1alpha1.GroupSync
-	Provider          *redhatcopv1alpha1.LdapProvider
+	GroupSync         *redhatcopv1beta1.GroupSync
+	Provider          *redhatcopv1beta1.LdapProvider
 	ReconcilerBase    util.ReconcilerBase
 	Context           context.Context
 	CredentialsSecret *corev1.Secret
@@ -54,13 +53,13 @@ func (l *LdapSyncer) Init() bool {
 	if l.Provider.Whitelist == nil {
 		l.Whitelist = []string{}
 	} else {
-		l.Whitelist = *l.Provider.Whitelist
+		l.Whitelist = l.Provider.Whitelist
 	}
 
 	if l.Provider.Blacklist == nil {
 		l.Blacklist = []string{}
 	} else {
-		l.Blacklist = *l.Provider.Blacklist
+		l.Blacklist = l.Provider.Blacklist
 	}
 
 	return false
@@ -70,8 +69,7 @@ func (l *LdapSyncer) Validate() error {
 	validationErrors := []error{}
 
 	if l.Provider.CredentialsSecret != nil {
-		credentialsSecret := &corev1.Secret{}
-		err := l.ReconcilerBase.GetClient().Get(l.Context, types.NamespacedName{Name: l.Provider.CredentialsSecret.Name, Namespace: l.Provider.CredentialsSecret.Namespace}, credentialsSecret)
+		credentialsSecret, err := getCredentialsSecret(l.Context, l.ReconcilerBase.GetClient(), l.GroupSync, l.Provider.CredentialsSecret)
 
 		if err != nil {
 			validationErrors = append(validationErrors, err)
@@ -81,10 +79,10 @@ func (l *LdapSyncer) Validate() error {
 
 	}
 
-	providerCaResource := determineFromDeprecatedObjectRef(l.Provider.Ca, l.Provider.CaSecret)
+	providerCaResource := l.Provider.Ca
 	if providerCaResource != nil {
 
-		caResource, err := getObjectRefData(l.Context, l.ReconcilerBase.GetClient(), providerCaResource)
+		caResource, err := getObjectRefData(l.Context, l.ReconcilerBase.GetClient(), l.GroupSync, providerCaResource)
 
 		if err != nil {
 			validationErrors = append(validationErrors, err)
@@ -105,16 +103,16 @@ func (l *LdapSyncer) Validate() error {
 		l.CaCertificate = caResource[resourceCaKey]
 	}
 
-	if l.Provider.URL == nil {
+	if l.Provider.URL == "" {
 		validationErrors = append(validationErrors, fmt.Errorf("LDAP URL must be provided"))
 	} else {
 
 		var err error
 
-		l.URL, err = url.Parse(*l.Provider.URL)
+		l.URL, err = url.Parse(l.Provider.URL)
 
 		if err != nil {
-			validationErrors = append(validationErrors, fmt.Errorf("Invalid LDAP URL: '%s'", *l.Provider.URL))
+			validationErrors = append(validationErrors, fmt.Errorf("Invalid LDAP URL: '%s'", l.Provider.URL))
 		}
 
 		if l.Provider.Insecure {
@@ -376,7 +374,7 @@ func (l *LdapSyncer) CreateErrorHandler() syncerror.Handler {
 	return syncerror.NewCompoundHandler(components...)
 }
 
-func buildSyncBuilder(clientConfig ldapclient.Config, provider *redhatcopv1alpha1.LdapProvider, errorHandler syncerror.Handler) (ldapbuilders.SyncBuilder, error) {
+func buildSyncBuilder(clientConfig ldapclient.Config, provider *redhatcopv1beta1.LdapProvider, errorHandler syncerror.Handler) (ldapbuilders.SyncBuilder, error) {
 	switch {
 	case provider.RFC2307Config != nil:
 		return &ldapbuilders.RFC2307Builder{ClientConfig: clientConfig, Config: provider.RFC2307Config, ErrorHandler: errorHandler}, nil
@@ -0,0 +1,79 @@
+package syncer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	userv1 "github.com/openshift/api/user/v1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+)
+
+// GetProviderLimits returns the limits configured for the provider with the given name
+func GetProviderLimits(groupSync *redhatcopv1beta1.GroupSync, providerName string) *redhatcopv1beta1.Limits {
+
+	for _, provider := range groupSync.Spec.Providers {
+		if provider.Name == providerName {
+			return provider.Limits
+		}
+	}
+
+	return nil
+}
+
+// EnforceLimits applies the limits of a provider to the groups it synchronized. The groups to apply are returned
+// along with the names of the groups that were skipped, which must be left unchanged, and the violations that occurred.
+// An error is returned when the synchronization of the provider must fail
+func EnforceLimits(providerName string, limits *redhatcopv1beta1.Limits, groups []userv1.Group) ([]userv1.Group, []string, []redhatcopv1beta1.LimitViolation, error) {
+
+	violations := []redhatcopv1beta1.LimitViolation{}
+
+	if limits == nil {
+		return groups, []string{}, violations, nil
+	}
+
+	if limits.MaxGroups > 0 && len(groups) > limits.MaxGroups {
+		violations = append(violations, redhatcopv1beta1.LimitViolation{Provider: providerName, Count: len(groups), Limit: limits.MaxGroups, Policy: redhatcopv1beta1.FailMemberLimitPolicy})
+		return nil, nil, violations, fmt.Errorf("Provider '%s' returned %d groups which exceeds the maximum of %d", providerName, len(groups), limits.MaxGroups)
+	}
+
+	if limits.MaxMembers == 0 {
+		return groups, []string{}, violations, nil
+	}
+
+	policy := limits.MemberLimitPolicy
+	if policy == "" {
+		policy = redhatcopv1beta1.SkipMemberLimitPolicy
+	}
+
+	allowedGroups := []userv1.Group{}
+	skippedGroups := []string{}
+	failedGroups := []string{}
+
+	for _, group := range groups {
+		if len(group.Users) <= limits.MaxMembers {
+			allowedGroups = append(allowedGroups, group)
+			continue
+		}
+
+		violations = append(violations, redhatcopv1beta1.LimitViolation{Provider: providerName, Group: group.Name, Count: len(group.Users), Limit: limits.MaxMembers, Policy: policy})
+
+		switch policy {
+		case redhatcopv1beta1.FailMemberLimitPolicy:
+			failedGroups = append(failedGroups, group.Name)
+		case redhatcopv1beta1.TruncateMemberLimitPolicy:
+			users := append([]string{}, group.Users...)
+			sort.Strings(users)
+			group.Users = users[:limits.MaxMembers]
+			allowedGroups = append(allowedGroups, group)
+		default:
+			skippedGroups = append(skippedGroups, group.Name)
+		}
+	}
+
+	if len(failedGroups) > 0 {
+		return nil, nil, violations, fmt.Errorf("Groups '%s' of provider '%s' exceed the maximum of %d members", strings.Join(failedGroups, "', '"), providerName, limits.MaxMembers)
+	}
+
+	return allowedGroups, skippedGroups, violations, nil
+}
@@ -0,0 +1,94 @@
+package syncer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	userv1 "github.com/openshift/api/user/v1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEnforceLimits(t *testing.T) {
+	groups := []userv1.Group{
+		{ObjectMeta: metav1.ObjectMeta{Name: "admins"}, Users: []string{"john", "jane"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "everyone"}, Users: []string{"joe", "john", "jim", "jane"}},
+	}
+
+	tests := []struct {
+		name            string
+		limits          *redhatcopv1beta1.Limits
+		expected        map[string][]string
+		expectedSkipped []string
+		expectedError   string
+		violations      int
+	}{
+		{
+			name:     "no limits",
+			expected: map[string][]string{"admins": {"john", "jane"}, "everyone": {"joe", "john", "jim", "jane"}},
+		},
+		{
+			name:            "skip",
+			limits:          &redhatcopv1beta1.Limits{MaxMembers: 3},
+			expected:        map[string][]string{"admins": {"john", "jane"}},
+			expectedSkipped: []string{"everyone"},
+			violations:      1,
+		},
+		{
+			name:       "truncate",
+			limits:     &redhatcopv1beta1.Limits{MaxMembers: 3, MemberLimitPolicy: redhatcopv1beta1.TruncateMemberLimitPolicy},
+			expected:   map[string][]string{"admins": {"john", "jane"}, "everyone": {"jane", "jim", "joe"}},
+			violations: 1,
+		},
+		{
+			name:          "fail",
+			limits:        &redhatcopv1beta1.Limits{MaxMembers: 1, MemberLimitPolicy: redhatcopv1beta1.FailMemberLimitPolicy},
+			expectedError: "Groups 'admins', 'everyone' of provider 'ldap' exceed the maximum of 1 members",
+			violations:    2,
+		},
+		{
+			name:          "maximum groups",
+			limits:        &redhatcopv1beta1.Limits{MaxGroups: 1},
+			expectedError: "Provider 'ldap' returned 2 groups which exceeds the maximum of 1",
+			violations:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowedGroups, skippedGroups, violations, err := EnforceLimits("ldap", tt.limits, groups)
+
+			if len(violations) != tt.violations {
+				t.Errorf("EnforceLimits() violations = %v, expected %d", violations, tt.violations)
+			}
+
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("EnforceLimits() error = %v, expected to contain '%s'", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EnforceLimits() error = %v", err)
+			}
+
+			actual := map[string][]string{}
+			for _, group := range allowedGroups {
+				actual[group.Name] = group.Users
+			}
+
+			if fmt.Sprint(actual) != fmt.Sprint(tt.expected) {
+				t.Errorf("EnforceLimits() groups = %v, expected %v", actual, tt.expected)
+			}
+
+			if fmt.Sprint(skippedGroups) != fmt.Sprint(tt.expectedSkipped) && len(skippedGroups)+len(tt.expectedSkipped) > 0 {
+				t.Errorf("EnforceLimits() skipped groups = %v, expected %v", skippedGroups, tt.expectedSkipped)
+			}
+		})
+	}
+
+	if groups[1].Users[0] != "joe" {
+		t.Errorf("EnforceLimits() modified the members of the provided groups")
+	}
+}
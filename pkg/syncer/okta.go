@@ -12,13 +12,12 @@ import (
 
 	"github.com/okta/okta-sdk-golang/v2/okta"
 	userv1 "github.com/openshift/api/user/v1"
-	"github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	"github.com/redhat-cop/group-sync-operator/api/v1beta1"
 	"github.com/redhat-cop/group-sync-operator/pkg/constants"
 	"github.com/redhat-cop/operator-utils/pkg/util"
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/validation"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -39,9 +38,11 @@ type OktaSyncer struct {
 	cachedGroupMembers map[string][]*okta.User
 	credentialsSecret  *corev1.Secret
 	goOkta             *okta.Client
-	GroupSync          *v1alpha1.GroupSync
+	httpClientConfig   *httpClientConfig
+	groupFilter        *groupNameFilter
+	GroupSync          *v1beta1.GroupSync
 	Name               string
-	Provider           *v1alpha1.OktaProvider
+	Provider           *v1beta1.OktaProvider
 	ReconcilerBase     util.ReconcilerBase
 }
 
@@ -81,6 +82,18 @@ func (o *OktaSyncer) Validate() error {
 		validationErrors = append(validationErrors, fmt.Errorf("credentialsSecret must be provided for Okta provider"))
 	}
 
+	httpClientConfig, err := newHTTPClientConfig(context.TODO(), o.ReconcilerBase.GetClient(), o.GroupSync, o.Provider.ProviderBase, o.Provider.HTTPClientOptions)
+	if err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+	o.httpClientConfig = httpClientConfig
+
+	groupFilter, err := newGroupNameFilter(o.Provider.Groups, o.Provider.GroupPatterns)
+	if err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+	o.groupFilter = groupFilter
+
 	if _, err := url.ParseRequestURI(o.Provider.URL); err != nil {
 		validationErrors = append(validationErrors, err)
 	}
@@ -89,21 +102,19 @@ func (o *OktaSyncer) Validate() error {
 }
 
 func (o OktaSyncer) getSecrets() (*corev1.Secret, error) {
-	credentialsSecret := &corev1.Secret{}
-	nameSpacedName := types.NamespacedName{
-		Name:      o.Provider.CredentialsSecret.Name,
-		Namespace: o.Provider.CredentialsSecret.Namespace,
-	}
-	err := o.ReconcilerBase.GetClient().Get(context.TODO(), nameSpacedName, credentialsSecret)
-	return credentialsSecret, err
+	return getCredentialsSecret(context.TODO(), o.ReconcilerBase.GetClient(), o.GroupSync, o.Provider.CredentialsSecret)
 }
 
 func (o *OktaSyncer) Bind() error {
 	var err error
 
-	_, o.goOkta, err = okta.NewClient(context.TODO(),
+	opts := []okta.ConfigSetter{
 		okta.WithOrgUrl(o.Provider.URL),
-		okta.WithToken(string(o.credentialsSecret.Data[secretOktaTokenKey])))
+		okta.WithToken(string(o.credentialsSecret.Data[secretOktaTokenKey])),
+		okta.WithHttpClientPtr(o.httpClientConfig.client()),
+	}
+
+	_, o.goOkta, err = okta.NewClient(context.TODO(), opts...)
 	if err != nil {
 		oktaLogger.Error(err, "establishing new okta client")
 		return err
@@ -260,7 +271,7 @@ func (o OktaSyncer) mapAttributes(group *okta.Group) map[string]string {
 
 func (o *OktaSyncer) processGroupsAndMembers(group *okta.Group) error {
 
-	if !isGroupAllowed(group.Profile.Name, o.Provider.Groups) {
+	if !o.groupFilter.allows(group.Profile.Name) {
 		return nil
 	}
 
@@ -0,0 +1,227 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	userv1 "github.com/openshift/api/user/v1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	staticLogger = logf.Log.WithName("syncer_static")
+)
+
+const (
+	defaultStaticGroupsKey = "groups.yaml"
+)
+
+// GroupFileDirectory is the directory containing the files that file providers are permitted to read
+var GroupFileDirectory = ""
+
+type StaticSyncer struct {
+	Name           string
+	GroupSync      *redhatcopv1beta1.GroupSync
+	Provider       *redhatcopv1beta1.StaticProvider
+	Context        context.Context
+	ReconcilerBase util.ReconcilerBase
+	Groups         []redhatcopv1beta1.StaticGroup
+}
+
+type FileSyncer struct {
+	Name      string
+	GroupSync *redhatcopv1beta1.GroupSync
+	Provider  *redhatcopv1beta1.FileProvider
+	Groups    []redhatcopv1beta1.StaticGroup
+}
+
+func (s *StaticSyncer) Init() bool {
+	s.Context = context.Background()
+	return false
+}
+
+func (s *StaticSyncer) Validate() error {
+
+	validationErrors := []error{}
+
+	s.Groups = append([]redhatcopv1beta1.StaticGroup{}, s.Provider.Groups...)
+
+	if s.Provider.Source != nil {
+		sourceGroups, err := s.getSourceGroups()
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+		s.Groups = append(s.Groups, sourceGroups...)
+	}
+
+	validationErrors = append(validationErrors, validateStaticGroups(s.Groups)...)
+
+	return utilerrors.NewAggregate(validationErrors)
+}
+
+// getSourceGroups returns the groups defined in the referenced ConfigMap or Secret
+func (s *StaticSyncer) getSourceGroups() ([]redhatcopv1beta1.StaticGroup, error) {
+
+	source := s.Provider.Source
+
+	sourceData, err := getObjectRefData(s.Context, s.ReconcilerBase.GetClient(), s.GroupSync, source)
+	if err != nil {
+		return nil, err
+	}
+
+	key := defaultStaticGroupsKey
+	if source.Key != "" {
+		key = source.Key
+	}
+
+	data, found := sourceData[key]
+	if !found {
+		return nil, fmt.Errorf("Could not find '%s' key in %s '%s' in namespace '%s'", key, source.Kind, source.Name, source.Namespace)
+	}
+
+	groups, err := parseStaticGroups(data)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid groups in %s '%s' in namespace '%s': %w", source.Kind, source.Name, source.Namespace, err)
+	}
+
+	return groups, nil
+}
+
+func (s *StaticSyncer) Bind() error {
+	return nil
+}
+
+func (s *StaticSyncer) Sync() ([]userv1.Group, error) {
+	staticLogger.Info("Retrieved static groups", "Provider", s.Name, "Groups", len(s.Groups))
+	return toOpenShiftGroups(s.Groups), nil
+}
+
+func (s *StaticSyncer) GetProviderName() string {
+	return s.Name
+}
+
+func (s *StaticSyncer) GetPrune() bool {
+	return s.Provider.Prune
+}
+
+func (f *FileSyncer) Init() bool {
+	return false
+}
+
+func (f *FileSyncer) Validate() error {
+
+	if GroupFileDirectory == "" {
+		return fmt.Errorf("File providers are disabled as no group file directory has been configured")
+	}
+
+	if !filepath.IsLocal(f.Provider.Path) {
+		return fmt.Errorf("Invalid path: '%s'. Path must be relative to the group file directory", f.Provider.Path)
+	}
+
+	return nil
+}
+
+func (f *FileSyncer) Bind() error {
+	return nil
+}
+
+func (f *FileSyncer) Sync() ([]userv1.Group, error) {
+
+	data, err := os.ReadFile(filepath.Join(GroupFileDirectory, f.Provider.Path))
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read group file '%s': %w", f.Provider.Path, err)
+	}
+
+	groups, err := parseStaticGroups(data)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid groups in file '%s': %w", f.Provider.Path, err)
+	}
+
+	if err := utilerrors.NewAggregate(validateStaticGroups(groups)); err != nil {
+		return nil, err
+	}
+
+	f.Groups = groups
+
+	staticLogger.Info("Retrieved groups from file", "Provider", f.Name, "Path", f.Provider.Path, "Groups", len(groups))
+
+	return toOpenShiftGroups(groups), nil
+}
+
+func (f *FileSyncer) GetProviderName() string {
+	return f.Name
+}
+
+func (f *FileSyncer) GetPrune() bool {
+	return f.Provider.Prune
+}
+
+// parseStaticGroups parses a YAML or JSON list of groups
+func parseStaticGroups(data []byte) ([]redhatcopv1beta1.StaticGroup, error) {
+
+	groups := []redhatcopv1beta1.StaticGroup{}
+	if err := yaml.UnmarshalStrict(data, &groups); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// validateStaticGroups verifies each group is named and defined once
+func validateStaticGroups(groups []redhatcopv1beta1.StaticGroup) []error {
+
+	validationErrors := []error{}
+	names := sets.New[string]()
+
+	for _, group := range groups {
+		if group.Name == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("Group name not provided"))
+			continue
+		}
+
+		if names.Has(group.Name) {
+			validationErrors = append(validationErrors, fmt.Errorf("Group '%s' is defined more than once", group.Name))
+		}
+		names.Insert(group.Name)
+	}
+
+	return validationErrors
+}
+
+func toOpenShiftGroups(groups []redhatcopv1beta1.StaticGroup) []userv1.Group {
+
+	ocpGroups := []userv1.Group{}
+
+	for _, group := range groups {
+		ocpGroup := userv1.Group{
+			TypeMeta: v1.TypeMeta{
+				Kind:       "Group",
+				APIVersion: userv1.GroupVersion.String(),
+			},
+			ObjectMeta: v1.ObjectMeta{
+				Name:        group.Name,
+				Annotations: map[string]string{},
+				Labels:      map[string]string{},
+			},
+			Users: []string{},
+		}
+
+		for key, value := range group.Annotations {
+			ocpGroup.Annotations[key] = value
+		}
+
+		ocpGroup.Users = append(ocpGroup.Users, group.Users...)
+
+		ocpGroups = append(ocpGroups, ocpGroup)
+	}
+
+	return ocpGroups
+}
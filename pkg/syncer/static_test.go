@@ -0,0 +1,160 @@
+package syncer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const staticTestGroups = `
+- name: developers
+  users:
+  - jim
+- name: operators
+  annotations:
+    example.com/team: platform
+`
+
+func TestStaticSyncer(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("unable to build scheme: %v", err)
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "groups", Namespace: testNamespace},
+			Data:       map[string]string{defaultStaticGroupsKey: staticTestGroups, "duplicate.yaml": "- name: admins"},
+		},
+	).Build()
+	reconcilerBase := util.NewReconcilerBase(k8sClient, scheme, nil, nil, k8sClient)
+
+	tests := []struct {
+		name          string
+		provider      redhatcopv1beta1.StaticProvider
+		expected      map[string][]string
+		expectedError string
+	}{
+		{
+			name: "inline groups",
+			provider: redhatcopv1beta1.StaticProvider{
+				Groups: []redhatcopv1beta1.StaticGroup{{Name: "admins", Users: []string{"jane", "john"}}},
+			},
+			expected: map[string][]string{"admins": {"jane", "john"}},
+		},
+		{
+			name: "inline and configmap groups",
+			provider: redhatcopv1beta1.StaticProvider{
+				Groups: []redhatcopv1beta1.StaticGroup{{Name: "admins", Users: []string{"jane", "john"}}},
+				Source: &redhatcopv1beta1.ObjectRef{Name: "groups", Namespace: testNamespace, Kind: redhatcopv1beta1.ConfigMapObjectRefKind},
+			},
+			expected: map[string][]string{"admins": {"jane", "john"}, "developers": {"jim"}, "operators": {}},
+		},
+		{
+			name: "duplicate groups",
+			provider: redhatcopv1beta1.StaticProvider{
+				Groups: []redhatcopv1beta1.StaticGroup{{Name: "admins"}},
+				Source: &redhatcopv1beta1.ObjectRef{Name: "groups", Namespace: testNamespace, Kind: redhatcopv1beta1.ConfigMapObjectRefKind, Key: "duplicate.yaml"},
+			},
+			expectedError: "Group 'admins' is defined more than once",
+		},
+		{
+			name: "missing key",
+			provider: redhatcopv1beta1.StaticProvider{
+				Source: &redhatcopv1beta1.ObjectRef{Name: "groups", Namespace: testNamespace, Kind: redhatcopv1beta1.ConfigMapObjectRefKind, Key: "missing.yaml"},
+			},
+			expectedError: "Could not find 'missing.yaml' key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			staticSyncer := &StaticSyncer{
+				Name:           "static",
+				GroupSync:      &redhatcopv1beta1.GroupSync{ObjectMeta: metav1.ObjectMeta{Name: "groupsync", Namespace: testNamespace}},
+				Provider:       tt.provider.DeepCopy(),
+				ReconcilerBase: reconcilerBase,
+			}
+
+			staticSyncer.Init()
+
+			err := staticSyncer.Validate()
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("Validate() error = %v, expected to contain '%s'", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+
+			groups, err := staticSyncer.Sync()
+			if err != nil {
+				t.Fatalf("Sync() error = %v", err)
+			}
+
+			actual := map[string][]string{}
+			for _, group := range groups {
+				actual[group.Name] = group.Users
+				if group.Name == "operators" && group.Annotations["example.com/team"] != "platform" {
+					t.Errorf("Sync() did not set the annotations of group '%s'", group.Name)
+				}
+			}
+
+			if fmt.Sprint(actual) != fmt.Sprint(tt.expected) {
+				t.Errorf("Sync() groups = %v, expected %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFileSyncer(t *testing.T) {
+	directory := t.TempDir()
+	if err := os.WriteFile(filepath.Join(directory, "groups.yaml"), []byte(staticTestGroups), 0600); err != nil {
+		t.Fatalf("unable to write group file: %v", err)
+	}
+
+	defer func(previous string) { GroupFileDirectory = previous }(GroupFileDirectory)
+
+	fileSyncer := &FileSyncer{
+		Name:     "file",
+		Provider: &redhatcopv1beta1.FileProvider{Path: "groups.yaml"},
+	}
+
+	GroupFileDirectory = ""
+	if err := fileSyncer.Validate(); err == nil {
+		t.Errorf("Validate() expected an error when no group file directory is configured")
+	}
+
+	GroupFileDirectory = directory
+
+	fileSyncer.Provider.Path = "../groups.yaml"
+	if err := fileSyncer.Validate(); err == nil {
+		t.Errorf("Validate() expected an error for a path outside the group file directory")
+	}
+
+	fileSyncer.Provider.Path = "groups.yaml"
+	if err := fileSyncer.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	groups, err := fileSyncer.Sync()
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	if len(groups) != 2 || groups[0].Name != "developers" || groups[0].Users[0] != "jim" {
+		t.Errorf("Sync() groups = %v", groups)
+	}
+}
@@ -7,7 +7,7 @@ import (
 	"time"
 
 	userv1 "github.com/openshift/api/user/v1"
-	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
 	"github.com/redhat-cop/group-sync-operator/pkg/provider/ibmsecurityverify"
 	"github.com/redhat-cop/operator-utils/pkg/util"
 	"github.com/robfig/cron/v3"
@@ -29,8 +29,12 @@ const (
 	privateKey            = "privateKey"
 	appId                 = "appId"
 	defaultResourceCaKey  = "ca.crt"
+	defaultKubeconfigKey  = "kubeconfig"
 )
 
+// RestrictObjectRefNamespace requires Secrets and ConfigMaps referenced by a GroupSync to be located in the namespace of the GroupSync
+var RestrictObjectRefNamespace = false
+
 type GroupSyncer interface {
 	GetProviderName() string
 	Init() bool
@@ -41,11 +45,12 @@ type GroupSyncer interface {
 }
 
 type GroupSyncMgr struct {
-	GroupSyncers []GroupSyncer
-	GroupSync    *redhatcopv1alpha1.GroupSync
+	GroupSyncers     []GroupSyncer
+	CompositeSyncers []*CompositeSyncer
+	GroupSync        *redhatcopv1beta1.GroupSync
 }
 
-func GetGroupSyncMgr(groupSync *redhatcopv1alpha1.GroupSync, reconcilerBase util.ReconcilerBase) (GroupSyncMgr, error) {
+func GetGroupSyncMgr(groupSync *redhatcopv1beta1.GroupSync, reconcilerBase util.ReconcilerBase) (GroupSyncMgr, error) {
 
 	syncers := []GroupSyncer{}
 	syncersError := []error{}
@@ -62,10 +67,17 @@ func GetGroupSyncMgr(groupSync *redhatcopv1alpha1.GroupSync, reconcilerBase util
 
 	}
 
-	return GroupSyncMgr{GroupSync: groupSync, GroupSyncers: syncers}, utilerrors.NewAggregate(syncersError)
+	compositeSyncers := []*CompositeSyncer{}
+
+	for i := range groupSync.Spec.Composites {
+		composite := &groupSync.Spec.Composites[i]
+		compositeSyncers = append(compositeSyncers, &CompositeSyncer{GroupSync: groupSync, Composite: composite, Name: composite.Name})
+	}
+
+	return GroupSyncMgr{GroupSync: groupSync, GroupSyncers: syncers, CompositeSyncers: compositeSyncers}, utilerrors.NewAggregate(syncersError)
 }
 
-func getGroupSyncerForProvider(groupSync *redhatcopv1alpha1.GroupSync, provider *redhatcopv1alpha1.Provider, reconcilerBase util.ReconcilerBase) (GroupSyncer, error) {
+func getGroupSyncerForProvider(groupSync *redhatcopv1beta1.GroupSync, provider *redhatcopv1beta1.Provider, reconcilerBase util.ReconcilerBase) (GroupSyncer, error) {
 
 	switch {
 	case provider.Okta != nil:
@@ -97,6 +109,22 @@ func getGroupSyncerForProvider(groupSync *redhatcopv1alpha1.GroupSync, provider
 			apiClient := &ibmsecurityverify.ApiClient{}
 			return &IbmSecurityVerifySyncer{GroupSync: groupSync, Provider: provider.IbmSecurityVerify, Name: provider.Name, ReconcilerBase: reconcilerBase, ApiClient: apiClient}, nil
 		}
+	case provider.External != nil:
+		{
+			return &ExternalSyncer{GroupSync: groupSync, Provider: provider.External, Name: provider.Name, ReconcilerBase: reconcilerBase}, nil
+		}
+	case provider.HTTP != nil:
+		{
+			return &HTTPSyncer{GroupSync: groupSync, Provider: provider.HTTP, Name: provider.Name, ReconcilerBase: reconcilerBase}, nil
+		}
+	case provider.Static != nil:
+		{
+			return &StaticSyncer{GroupSync: groupSync, Provider: provider.Static, Name: provider.Name, ReconcilerBase: reconcilerBase}, nil
+		}
+	case provider.File != nil:
+		{
+			return &FileSyncer{GroupSync: groupSync, Provider: provider.File, Name: provider.Name}, nil
+		}
 	}
 	return nil, fmt.Errorf("Could not find syncer for provider '%s'", provider.Name)
 }
@@ -127,6 +155,27 @@ func (m *GroupSyncMgr) Validate() error {
 		}
 	}
 
+	// Validate Referenced Resources Are Located in the Namespace of the GroupSync
+	if RestrictObjectRefNamespace {
+		for _, provider := range m.GroupSync.Spec.Providers {
+			for _, objectRef := range getProviderObjectRefs(&provider) {
+				if err := validateObjectRefNamespace(m.GroupSync, objectRef); err != nil {
+					syncersError = append(syncersError, fmt.Errorf("provider '%s': %w", provider.Name, err))
+				}
+			}
+		}
+	}
+
+	for _, target := range m.GroupSync.Spec.Targets {
+		if target.KubeconfigSecret == nil {
+			syncersError = append(syncersError, fmt.Errorf("target '%s': kubeconfig secret must be specified", target.Name))
+		} else if target.KubeconfigSecret.Kind == "" {
+			syncersError = append(syncersError, fmt.Errorf("target '%s': kubeconfig secret kind must be specified", target.Name))
+		} else if err := validateObjectRefNamespace(m.GroupSync, target.KubeconfigSecret); err != nil {
+			syncersError = append(syncersError, fmt.Errorf("target '%s': %w", target.Name, err))
+		}
+	}
+
 	for _, syncer := range m.GroupSyncers {
 		err := syncer.Validate()
 
@@ -136,69 +185,199 @@ func (m *GroupSyncMgr) Validate() error {
 
 	}
 
+	for _, compositeSyncer := range m.CompositeSyncers {
+		if err := compositeSyncer.Validate(); err != nil {
+			syncersError = append(syncersError, err)
+		}
+	}
+
 	return utilerrors.NewAggregate(syncersError)
 
 }
 
-func isGroupAllowed(groupName string, allowedGroups []string) bool {
-	if allowedGroups == nil || len(allowedGroups) == 0 {
-		return true
+// GetTargetKubeconfig returns the kubeconfig used to communicate with a remote target cluster along with the
+// resourceVersion of the resource containing it
+func GetTargetKubeconfig(context context.Context, client client.Client, groupSync *redhatcopv1beta1.GroupSync, target *redhatcopv1beta1.Target) ([]byte, string, error) {
+
+	kubeconfigData, resourceVersion, err := getVersionedObjectRefData(context, client, groupSync, target.KubeconfigSecret)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	kubeconfigKey := defaultKubeconfigKey
+	if target.KubeconfigSecret.Key != "" {
+		kubeconfigKey = target.KubeconfigSecret.Key
 	}
 
-	for _, allowedGroup := range allowedGroups {
-		if allowedGroup == groupName {
-			return true
-		}
+	kubeconfig, found := kubeconfigData[kubeconfigKey]
+	if !found {
+		return nil, "", fmt.Errorf("Could not find '%s' key in %s '%s' in namespace '%s'", kubeconfigKey, target.KubeconfigSecret.Kind, target.KubeconfigSecret.Name, target.KubeconfigSecret.Namespace)
 	}
 
-	return false
+	return kubeconfig, resourceVersion, nil
+}
+
+func getObjectRefData(context context.Context, client client.Client, groupSync *redhatcopv1beta1.GroupSync, resource *redhatcopv1beta1.ObjectRef) (map[string][]byte, error) {
+
+	data, _, err := getVersionedObjectRefData(context, client, groupSync, resource)
+
+	return data, err
 }
 
-func getObjectRefData(context context.Context, client client.Client, resource *redhatcopv1alpha1.ObjectRef) (map[string][]byte, error) {
+// getVersionedObjectRefData returns the data of the referenced Secret or ConfigMap along with its resourceVersion
+func getVersionedObjectRefData(context context.Context, client client.Client, groupSync *redhatcopv1beta1.GroupSync, resource *redhatcopv1beta1.ObjectRef) (map[string][]byte, string, error) {
 
-	if resource.Kind != "" && resource.Kind == redhatcopv1alpha1.ConfigMapObjectRefKind {
+	if err := validateObjectRefNamespace(groupSync, resource); err != nil {
+		return nil, "", err
+	}
+
+	if resource.Kind != "" && resource.Kind == redhatcopv1beta1.ConfigMapObjectRefKind {
 		caConfigMap := &corev1.ConfigMap{}
 		err := client.Get(context, types.NamespacedName{Name: resource.Name, Namespace: resource.Namespace}, caConfigMap)
 
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
 		configMapMap := map[string][]byte{}
 
 		if caConfigMap.Data == nil {
-			return nil, nil
+			return nil, caConfigMap.ResourceVersion, nil
 		}
 
 		for k, v := range caConfigMap.Data {
 			configMapMap[k] = []byte(v)
 		}
 
-		return configMapMap, nil
+		return configMapMap, caConfigMap.ResourceVersion, nil
 
 	} else if resource.Kind != "" {
 		caSecret := &corev1.Secret{}
 		err := client.Get(context, types.NamespacedName{Name: resource.Name, Namespace: resource.Namespace}, caSecret)
 
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
-		return caSecret.Data, nil
+		return caSecret.Data, caSecret.ResourceVersion, nil
+
+	}
+
+	return nil, "", nil
+}
+
+// getCaCertificate returns the CA certificate contained in the referenced Secret or ConfigMap
+func getCaCertificate(context context.Context, client client.Client, groupSync *redhatcopv1beta1.GroupSync, resource *redhatcopv1beta1.ObjectRef) ([]byte, error) {
+
+	caResource, err := getObjectRefData(context, client, groupSync, resource)
 
+	if err != nil {
+		return nil, err
+	}
+
+	resourceCaKey := defaultResourceCaKey
+	if resource.Key != "" {
+		resourceCaKey = resource.Key
+	}
+
+	caCertificate, found := caResource[resourceCaKey]
+	if !found {
+		return nil, fmt.Errorf("Could not find '%s' key in %s '%s' in namespace '%s'", resourceCaKey, resource.Kind, resource.Name, resource.Namespace)
+	}
+
+	return caCertificate, nil
+}
+
+func getCredentialsSecret(context context.Context, client client.Client, groupSync *redhatcopv1beta1.GroupSync, resource *redhatcopv1beta1.ObjectRef) (*corev1.Secret, error) {
+
+	if err := validateObjectRefNamespace(groupSync, resource); err != nil {
+		return nil, err
+	}
+
+	credentialsSecret := &corev1.Secret{}
+	err := client.Get(context, types.NamespacedName{Name: resource.Name, Namespace: resource.Namespace}, credentialsSecret)
+
+	return credentialsSecret, err
+}
+
+func validateObjectRefNamespace(groupSync *redhatcopv1beta1.GroupSync, resource *redhatcopv1beta1.ObjectRef) error {
+
+	if RestrictObjectRefNamespace && resource != nil && resource.Namespace != groupSync.Namespace {
+		return fmt.Errorf("%s '%s' must be located in namespace '%s' of the GroupSync, found '%s'", getObjectRefKind(resource), resource.Name, groupSync.Namespace, resource.Namespace)
+	}
+
+	return nil
+}
+
+func getObjectRefKind(resource *redhatcopv1beta1.ObjectRef) redhatcopv1beta1.ObjectRefKind {
+
+	if resource.Kind == "" {
+		return redhatcopv1beta1.SecretMapObjectRefKind
 	}
 
-	return nil, nil
+	return resource.Kind
 }
 
-func determineFromDeprecatedObjectRef(objectRef *redhatcopv1alpha1.ObjectRef, deprecatedObjectRef *redhatcopv1alpha1.ObjectRef) *redhatcopv1alpha1.ObjectRef {
+// getProviderObjectRefs returns all Secrets and ConfigMaps referenced by a provider
+func getProviderObjectRefs(provider *redhatcopv1beta1.Provider) []*redhatcopv1beta1.ObjectRef {
+
+	objectRefs := []*redhatcopv1beta1.ObjectRef{}
+
+	if provider.ProviderType == nil {
+		return objectRefs
+	}
+
+	switch {
+	case provider.Azure != nil:
+		objectRefs = append(objectRefs, provider.Azure.Ca, provider.Azure.CredentialsSecret)
+		objectRefs = append(objectRefs, getHTTPClientObjectRefs(provider.Azure.HTTPClientOptions)...)
+	case provider.GitHub != nil:
+		objectRefs = append(objectRefs, provider.GitHub.Ca, provider.GitHub.CredentialsSecret)
+		objectRefs = append(objectRefs, getHTTPClientObjectRefs(provider.GitHub.HTTPClientOptions)...)
+	case provider.GitLab != nil:
+		objectRefs = append(objectRefs, provider.GitLab.Ca, provider.GitLab.CredentialsSecret)
+		objectRefs = append(objectRefs, getHTTPClientObjectRefs(provider.GitLab.HTTPClientOptions)...)
+	case provider.Ldap != nil:
+		objectRefs = append(objectRefs, provider.Ldap.Ca, provider.Ldap.CredentialsSecret)
+	case provider.Keycloak != nil:
+		objectRefs = append(objectRefs, provider.Keycloak.Ca, provider.Keycloak.CredentialsSecret)
+		objectRefs = append(objectRefs, getHTTPClientObjectRefs(provider.Keycloak.HTTPClientOptions)...)
+	case provider.Okta != nil:
+		objectRefs = append(objectRefs, provider.Okta.Ca, provider.Okta.CredentialsSecret)
+		objectRefs = append(objectRefs, getHTTPClientObjectRefs(provider.Okta.HTTPClientOptions)...)
+	case provider.IbmSecurityVerify != nil:
+		objectRefs = append(objectRefs, provider.IbmSecurityVerify.Ca, provider.IbmSecurityVerify.CredentialsSecret)
+		objectRefs = append(objectRefs, getHTTPClientObjectRefs(provider.IbmSecurityVerify.HTTPClientOptions)...)
+	case provider.External != nil:
+		objectRefs = append(objectRefs, provider.External.Ca, provider.External.CredentialsSecret)
+	case provider.HTTP != nil:
+		objectRefs = append(objectRefs, provider.HTTP.Ca, provider.HTTP.CredentialsSecret)
+		objectRefs = append(objectRefs, getHTTPClientObjectRefs(provider.HTTP.HTTPClientOptions)...)
+	case provider.Static != nil:
+		objectRefs = append(objectRefs, provider.Static.Source)
+	}
 
-	if objectRef != nil {
-		return objectRef
+	nonNilObjectRefs := []*redhatcopv1beta1.ObjectRef{}
+	for _, objectRef := range objectRefs {
+		if objectRef != nil {
+			nonNilObjectRefs = append(nonNilObjectRefs, objectRef)
+		}
 	}
 
-	return deprecatedObjectRef
+	return nonNilObjectRefs
+}
+
+// getHTTPClientObjectRefs returns all Secrets referenced by the HTTP client options of a provider
+func getHTTPClientObjectRefs(options redhatcopv1beta1.HTTPClientOptions) []*redhatcopv1beta1.ObjectRef {
+
+	objectRefs := []*redhatcopv1beta1.ObjectRef{options.ClientCertificate}
+
+	if options.Proxy != nil {
+		objectRefs = append(objectRefs, options.Proxy.CredentialsSecret)
+	}
 
+	return objectRefs
 }
 
 func getSecretOrEnvValue(secret *corev1.Secret, key string) (string, bool) {
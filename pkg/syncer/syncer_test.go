@@ -0,0 +1,210 @@
+package syncer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const otherNamespace = "other"
+
+// setRestrictObjectRefNamespace sets the restriction for the duration of a test
+func setRestrictObjectRefNamespace(t *testing.T, restrict bool) {
+	t.Helper()
+
+	previous := RestrictObjectRefNamespace
+	RestrictObjectRefNamespace = restrict
+	t.Cleanup(func() { RestrictObjectRefNamespace = previous })
+}
+
+func newTestGroupSync(providers ...redhatcopv1beta1.Provider) *redhatcopv1beta1.GroupSync {
+	return &redhatcopv1beta1.GroupSync{
+		ObjectMeta: metav1.ObjectMeta{Name: "groupsync", Namespace: testNamespace},
+		Spec:       redhatcopv1beta1.GroupSyncSpec{Providers: providers},
+	}
+}
+
+func TestValidateObjectRefNamespace(t *testing.T) {
+	tests := []struct {
+		name          string
+		restrict      bool
+		namespace     string
+		expectedError bool
+	}{
+		{name: "same namespace unrestricted", restrict: false, namespace: testNamespace},
+		{name: "cross namespace unrestricted", restrict: false, namespace: otherNamespace},
+		{name: "empty namespace unrestricted", restrict: false, namespace: ""},
+		{name: "same namespace restricted", restrict: true, namespace: testNamespace},
+		{name: "cross namespace restricted", restrict: true, namespace: otherNamespace, expectedError: true},
+		{name: "empty namespace restricted", restrict: true, namespace: "", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setRestrictObjectRefNamespace(t, tt.restrict)
+
+			err := validateObjectRefNamespace(newTestGroupSync(), &redhatcopv1beta1.ObjectRef{Name: "credentials", Namespace: tt.namespace})
+			if tt.expectedError != (err != nil) {
+				t.Errorf("validateObjectRefNamespace() error = %v, expected error %t", err, tt.expectedError)
+			}
+		})
+	}
+
+	// A missing reference is never rejected
+	setRestrictObjectRefNamespace(t, true)
+	if err := validateObjectRefNamespace(newTestGroupSync(), nil); err != nil {
+		t.Errorf("validateObjectRefNamespace() error = %v for a nil reference", err)
+	}
+}
+
+func TestGetObjectRefRestricted(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: testNamespace}, Data: map[string][]byte{"token": []byte("local")}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: otherNamespace}, Data: map[string][]byte{"token": []byte("other")}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: otherNamespace}, Data: map[string]string{defaultResourceCaKey: "certificate"}},
+	).Build()
+
+	tests := []struct {
+		name          string
+		restrict      bool
+		namespace     string
+		expectedToken string
+	}{
+		{name: "same namespace unrestricted", restrict: false, namespace: testNamespace, expectedToken: "local"},
+		{name: "cross namespace unrestricted", restrict: false, namespace: otherNamespace, expectedToken: "other"},
+		{name: "same namespace restricted", restrict: true, namespace: testNamespace, expectedToken: "local"},
+		{name: "cross namespace restricted", restrict: true, namespace: otherNamespace},
+		{name: "empty namespace restricted", restrict: true, namespace: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setRestrictObjectRefNamespace(t, tt.restrict)
+
+			secret, err := getCredentialsSecret(context.TODO(), fakeClient, newTestGroupSync(), &redhatcopv1beta1.ObjectRef{Name: "credentials", Namespace: tt.namespace})
+
+			if tt.expectedToken == "" {
+				if err == nil || !strings.Contains(err.Error(), "must be located in namespace") {
+					t.Errorf("getCredentialsSecret() error = %v, expected namespace restriction", err)
+				}
+				if secret != nil {
+					t.Errorf("getCredentialsSecret() returned a secret despite the namespace restriction")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("getCredentialsSecret() error = %v", err)
+			}
+			if string(secret.Data["token"]) != tt.expectedToken {
+				t.Errorf("getCredentialsSecret() token = '%s', expected '%s'", secret.Data["token"], tt.expectedToken)
+			}
+		})
+	}
+
+	// CA certificates are read through the same restriction
+	caRef := &redhatcopv1beta1.ObjectRef{Name: "ca", Namespace: otherNamespace, Kind: redhatcopv1beta1.ConfigMapObjectRefKind}
+
+	setRestrictObjectRefNamespace(t, false)
+	if caCertificate, err := getCaCertificate(context.TODO(), fakeClient, newTestGroupSync(), caRef); err != nil || string(caCertificate) != "certificate" {
+		t.Errorf("getCaCertificate() = '%s', error = %v", caCertificate, err)
+	}
+
+	setRestrictObjectRefNamespace(t, true)
+	if _, err := getCaCertificate(context.TODO(), fakeClient, newTestGroupSync(), caRef); err == nil {
+		t.Errorf("getCaCertificate() expected namespace restriction error")
+	}
+}
+
+func TestValidateProviderObjectRefNamespaces(t *testing.T) {
+	ref := func() *redhatcopv1beta1.ObjectRef {
+		return &redhatcopv1beta1.ObjectRef{Name: "resource", Namespace: otherNamespace}
+	}
+	base := func() redhatcopv1beta1.ProviderBase {
+		return redhatcopv1beta1.ProviderBase{Ca: ref(), CredentialsSecret: ref()}
+	}
+	httpClientOptions := func() redhatcopv1beta1.HTTPClientOptions {
+		return redhatcopv1beta1.HTTPClientOptions{ClientCertificate: ref(), Proxy: &redhatcopv1beta1.Proxy{URL: "http://proxy:3128", CredentialsSecret: ref()}}
+	}
+
+	tests := []struct {
+		name         string
+		providerType redhatcopv1beta1.ProviderType
+		expectedRefs int
+	}{
+		{name: "azure", providerType: redhatcopv1beta1.ProviderType{Azure: &redhatcopv1beta1.AzureProvider{ProviderBase: base(), HTTPClientOptions: httpClientOptions()}}, expectedRefs: 4},
+		{name: "github", providerType: redhatcopv1beta1.ProviderType{GitHub: &redhatcopv1beta1.GitHubProvider{ProviderBase: base(), HTTPClientOptions: httpClientOptions()}}, expectedRefs: 4},
+		{name: "gitlab", providerType: redhatcopv1beta1.ProviderType{GitLab: &redhatcopv1beta1.GitLabProvider{ProviderBase: base(), HTTPClientOptions: httpClientOptions()}}, expectedRefs: 4},
+		{name: "ldap", providerType: redhatcopv1beta1.ProviderType{Ldap: &redhatcopv1beta1.LdapProvider{ProviderBase: base()}}, expectedRefs: 2},
+		{name: "keycloak", providerType: redhatcopv1beta1.ProviderType{Keycloak: &redhatcopv1beta1.KeycloakProvider{ProviderBase: base(), HTTPClientOptions: httpClientOptions()}}, expectedRefs: 4},
+		{name: "okta", providerType: redhatcopv1beta1.ProviderType{Okta: &redhatcopv1beta1.OktaProvider{ProviderBase: base(), HTTPClientOptions: httpClientOptions()}}, expectedRefs: 4},
+		{name: "ibmsecurityverify", providerType: redhatcopv1beta1.ProviderType{IbmSecurityVerify: &redhatcopv1beta1.IbmSecurityVerifyProvider{ProviderBase: base(), HTTPClientOptions: httpClientOptions()}}, expectedRefs: 4},
+		{name: "external", providerType: redhatcopv1beta1.ProviderType{External: &redhatcopv1beta1.ExternalProvider{ProviderBase: base()}}, expectedRefs: 2},
+		{name: "http", providerType: redhatcopv1beta1.ProviderType{HTTP: &redhatcopv1beta1.HTTPProvider{ProviderBase: base(), HTTPClientOptions: httpClientOptions()}}, expectedRefs: 4},
+		{name: "static", providerType: redhatcopv1beta1.ProviderType{Static: &redhatcopv1beta1.StaticProvider{Source: ref()}}, expectedRefs: 1},
+		{name: "file", providerType: redhatcopv1beta1.ProviderType{File: &redhatcopv1beta1.FileProvider{}}, expectedRefs: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := redhatcopv1beta1.Provider{Name: tt.name, ProviderType: &tt.providerType}
+
+			if objectRefs := getProviderObjectRefs(&provider); len(objectRefs) != tt.expectedRefs {
+				t.Fatalf("getProviderObjectRefs() returned %d references, expected %d", len(objectRefs), tt.expectedRefs)
+			}
+
+			// Unrestricted, cross namespace references are permitted
+			setRestrictObjectRefNamespace(t, false)
+			groupSyncMgr := GroupSyncMgr{GroupSync: newTestGroupSync(provider)}
+			if err := groupSyncMgr.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+
+			// Restricted, every cross namespace reference is reported
+			setRestrictObjectRefNamespace(t, true)
+			err := groupSyncMgr.Validate()
+
+			errorCount := 0
+			if aggregate, ok := err.(utilerrors.Aggregate); ok {
+				errorCount = len(aggregate.Errors())
+			}
+			if errorCount != tt.expectedRefs {
+				t.Errorf("Validate() error = %v, expected %d namespace errors", err, tt.expectedRefs)
+			}
+		})
+	}
+}
+
+func TestValidateTargetObjectRefNamespace(t *testing.T) {
+	groupSync := newTestGroupSync()
+	groupSync.Spec.Targets = []redhatcopv1beta1.Target{{Name: "remote", KubeconfigSecret: &redhatcopv1beta1.ObjectRef{Kind: redhatcopv1beta1.SecretMapObjectRefKind, Name: "kubeconfig", Namespace: otherNamespace}}}
+	groupSyncMgr := GroupSyncMgr{GroupSync: groupSync}
+
+	setRestrictObjectRefNamespace(t, false)
+	if err := groupSyncMgr.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	setRestrictObjectRefNamespace(t, true)
+	if err := groupSyncMgr.Validate(); err == nil || !strings.Contains(err.Error(), "target 'remote'") {
+		t.Errorf("Validate() error = %v, expected target namespace restriction", err)
+	}
+}
+
+func TestValidateTargetObjectRefKind(t *testing.T) {
+	groupSync := newTestGroupSync()
+	groupSync.Spec.Targets = []redhatcopv1beta1.Target{{Name: "remote", KubeconfigSecret: &redhatcopv1beta1.ObjectRef{Name: "kubeconfig", Namespace: groupSync.Namespace}}}
+	groupSyncMgr := GroupSyncMgr{GroupSync: groupSync}
+
+	setRestrictObjectRefNamespace(t, false)
+	if err := groupSyncMgr.Validate(); err == nil || !strings.Contains(err.Error(), "target 'remote': kubeconfig secret kind must be specified") {
+		t.Errorf("Validate() error = %v, expected missing kubeconfig secret kind", err)
+	}
+}
@@ -1,9 +1,9 @@
 package validation
 
 import (
-	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
 )
 
-func ValidateProviders(providers []redhatcopv1alpha1.Provider) error {
+func ValidateProviders(providers []redhatcopv1beta1.Provider) error {
 	return nil
 }